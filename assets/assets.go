@@ -0,0 +1,53 @@
+package assets
+
+// This represents the files the game needs at runtime, i.e. the dictionaries and images. They are embedded into the
+// binary, so it runs from any working directory. Files in an override directory take precedence over the embedded ones.
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+//go:embed base_tile.svg grey_tile.svg dicts/*.dawg
+var embedded embed.FS
+
+var (
+	overrideDirectory string
+	overrideMutex     sync.RWMutex
+)
+
+// SetOverrideDirectory makes the assets look up files in the directory first. Files missing in the directory are taken
+// from the embedded assets. An empty directory removes the override.
+func SetOverrideDirectory(directory string) {
+	overrideMutex.Lock()
+	defer overrideMutex.Unlock()
+	overrideDirectory = directory
+}
+
+// FS returns the file system of the assets, e.g. to load a dictionary with names like "dicts/en.dawg"
+func FS() fs.FS {
+	return assetFS{}
+}
+
+// ReadFile reads the asset with the given name, e.g. "base_tile.svg"
+func ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(FS(), name)
+}
+
+type assetFS struct{}
+
+func (assetFS) Open(name string) (fs.File, error) {
+	overrideMutex.RLock()
+	directory := overrideDirectory
+	overrideMutex.RUnlock()
+	if directory != "" {
+		file, err := os.DirFS(directory).Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return file, err
+		}
+	}
+	return embedded.Open(name)
+}
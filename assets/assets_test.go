@@ -0,0 +1,35 @@
+package assets
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAssets(t *testing.T) {
+	t.Run("Embedded assets are found", func(t *testing.T) {
+		for _, name := range []string{"base_tile.svg", "grey_tile.svg", "dicts/en.dawg", "dicts/de2.dawg"} {
+			data, err := ReadFile(name)
+			assert.NoError(t, err, name)
+			assert.NotEmpty(t, data, name)
+		}
+		_, err := ReadFile("dicts/en.csv")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("Override directory takes precedence", func(t *testing.T) {
+		directory := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(directory, "base_tile.svg"), []byte("<svg/>"), 0644))
+		SetOverrideDirectory(directory)
+		defer SetOverrideDirectory("")
+
+		data, err := ReadFile("base_tile.svg")
+		assert.NoError(t, err)
+		assert.Equal(t, "<svg/>", string(data))
+		data, err = ReadFile("grey_tile.svg")
+		assert.NoError(t, err)
+		assert.NotEqual(t, "<svg/>", string(data))
+	})
+}
@@ -0,0 +1,3 @@
+module assets
+
+go 1.21
@@ -0,0 +1,99 @@
+package bot
+
+// This represents the value of the leave, the tiles left on the rack after a move. A move scoring a few points less is
+// often the better move if it leaves a rack which is likely to score well on the next turn.
+
+import (
+	"game"
+	"math"
+)
+
+// LeaveWeights weigh the tiles left on the rack
+type LeaveWeights struct {
+	// Vowels are the letters counted as vowels for the balance of the rack
+	Vowels map[string]bool
+	// VowelRatio is the share of vowels of a balanced rack
+	VowelRatio float64
+	// Imbalance is the penalty per vowel or consonant more than in a balanced rack
+	Imbalance float64
+	// Duplicate is the penalty per tile with the same letter as another tile of the rack
+	Duplicate float64
+	// Keepers are the values of tiles worth keeping by letter, e.g. blanks and the S
+	Keepers map[string]float64
+}
+
+// DefaultLeaveWeights returns leave weights suited for the tile sets of all languages
+func DefaultLeaveWeights() LeaveWeights {
+	return LeaveWeights{
+		Vowels: map[string]bool{
+			"A": true,
+			"E": true,
+			"I": true,
+			"O": true,
+			"U": true,
+			"Ä": true,
+			"Ö": true,
+			"Ü": true,
+		},
+		VowelRatio: 0.4,
+		Imbalance:  2.5,
+		Duplicate:  3,
+		Keepers: map[string]float64{
+			game.BlankLetter: 20,
+			"S":              7,
+		},
+	}
+}
+
+// Value returns the value of the leave. Blanks count neither as vowels nor as consonants.
+func (weights LeaveWeights) Value(leave []game.Tile) float64 {
+	value := 0.0
+	vowels, letters := 0, 0
+	seen := make(map[string]bool, len(leave))
+	for _, tile := range leave {
+		if seen[tile.Letter] {
+			value -= weights.Duplicate
+		} else {
+			value += weights.Keepers[tile.Letter]
+		}
+		seen[tile.Letter] = true
+		if tile.IsBlank() {
+			continue
+		}
+		letters++
+		if weights.Vowels[tile.Letter] {
+			vowels++
+		}
+	}
+	value -= weights.Imbalance * math.Abs(float64(vowels)-weights.VowelRatio*float64(letters))
+	return value
+}
+
+// Keep returns the tiles of the rack worth keeping when exchanging the others, which are the keepers without their
+// duplicates
+func (weights LeaveWeights) Keep(rack []game.Tile) []game.Tile {
+	keep := make([]game.Tile, 0)
+	seen := make(map[string]bool, len(rack))
+	for _, tile := range rack {
+		if weights.Keepers[tile.Letter] > 0 && !seen[tile.Letter] {
+			keep = append(keep, tile)
+			seen[tile.Letter] = true
+		}
+	}
+	return keep
+}
+
+// leave returns the tiles of the rack which are not placed by the move
+func leave(rack []game.Tile, move []game.Move) []game.Tile {
+	placed := make(map[int]bool, len(move))
+	for _, m := range move {
+		placed[m.Tile.ID] = true
+	}
+	left := make([]game.Tile, 0, len(rack))
+	for _, tile := range rack {
+		if !placed[tile.ID] {
+			left = append(left, tile)
+		}
+	}
+	return left
+}
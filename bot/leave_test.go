@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// newTestRack creates tiles with unique IDs for the letters
+func newTestRack(letters ...string) []game.Tile {
+	rack := make([]game.Tile, len(letters))
+	for i, letter := range letters {
+		rack[i] = game.Tile{ID: 1000 + i, Letter: letter, LetterScore: game.LetterScores[letter]}
+	}
+	return rack
+}
+
+func TestLeaveWeights(t *testing.T) {
+	weights := DefaultLeaveWeights()
+
+	t.Run("Balanced racks are worth more", func(t *testing.T) {
+		assert.Greater(t, weights.Value(newTestRack("A", "E", "R", "T", "N")), weights.Value(newTestRack("A", "E", "I", "O", "U")))
+		assert.Greater(t, weights.Value(newTestRack("A", "E", "R", "T", "N")), weights.Value(newTestRack("B", "C", "D", "F", "G")))
+	})
+
+	t.Run("Duplicates are penalized", func(t *testing.T) {
+		assert.Greater(t, weights.Value(newTestRack("A", "R", "T")), weights.Value(newTestRack("A", "T", "T")))
+		assert.Equal(t, weights.Value(newTestRack("A", "R", "T"))-weights.Duplicate,
+			weights.Value(newTestRack("A", "R", "R")))
+	})
+
+	t.Run("Blanks and the S are kept", func(t *testing.T) {
+		assert.Greater(t, weights.Value(newTestRack("A", "R", game.BlankLetter)), weights.Value(newTestRack("A", "R", "T")))
+		assert.Greater(t, weights.Value(newTestRack("A", "R", "S")), weights.Value(newTestRack("A", "R", "T")))
+		rack := newTestRack("S", "Q", "S", game.BlankLetter, "V")
+		assert.Equal(t, []game.Tile{rack[0], rack[3]}, weights.Keep(rack))
+	})
+
+	t.Run("Leaves are the tiles not placed", func(t *testing.T) {
+		rack := newTestRack("C", "A", "R", "E")
+		move := []game.Move{{X: 7, Y: 7, Tile: &rack[1]}, {X: 8, Y: 7, Tile: &rack[3]}}
+		assert.Equal(t, []game.Tile{rack[0], rack[2]}, leave(rack, move))
+	})
+}
@@ -0,0 +1,234 @@
+package bot
+
+// This represents the simulation strategy. The best moves by static evaluation are played out a few turns ahead many
+// times. Each time, the tiles the player cannot see are shuffled and dealt to the opponents and drawn from the bag, and
+// all players play their best static move. The move with the best mean spread over all simulations is played. Once the
+// bag is empty, the end game is solved instead.
+
+import (
+	"game"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Position is a game as seen by the player to move. It holds copies of the board and the tiles, so it can be analyzed
+// while the game goes on.
+type Position struct {
+	Board *game.Board
+	Rack  []game.Tile
+	// Unseen holds the tiles in the bag and on the racks of the opponents
+	Unseen []game.Tile
+	// BagSize is the number of tiles in the bag
+	BagSize int
+	// Opponents is the number of players besides the player to move
+	Opponents int
+}
+
+// NewPosition takes a snapshot of the game as seen by the player. It must be called while viewing the game.
+func NewPosition(myGame *game.Game, player *game.Player) *Position {
+	position := &Position{
+		Board:   myGame.Board.Copy(),
+		Rack:    append([]game.Tile{}, player.Tiles...),
+		Unseen:  append([]game.Tile{}, myGame.Bag.Tiles...),
+		BagSize: len(myGame.Bag.Tiles),
+	}
+	for _, opponent := range myGame.Players {
+		if opponent != player {
+			position.Unseen = append(position.Unseen, opponent.Tiles...)
+			position.Opponents++
+		}
+	}
+	return position
+}
+
+// Simulation is the outcome of simulating a candidate move
+type Simulation struct {
+	Candidate  Candidate
+	Iterations int
+	// Spread is the mean of the points the player scored more than the opponents in the simulated turns, including the
+	// move itself
+	Spread float64
+}
+
+// SimulationStrategy implements game.Strategy by simulating the best moves
+type SimulationStrategy struct {
+	// Candidates is the number of the best moves by static evaluation which are simulated
+	Candidates int
+	// Plies is the number of turns simulated after the move, taken by the opponents and the player in turn order
+	Plies int
+	// Budget is the time the simulation of a position may take
+	Budget time.Duration
+	// Iterations limits the number of simulations per candidate, no limit if zero
+	Iterations int
+	// Workers is the number of goroutines simulating in parallel
+	Workers int
+	// static ranks the candidates and plays the simulated turns
+	static *StaticStrategy
+	random *rand.Rand
+}
+
+// NewSimulationStrategy creates a strategy simulating the ten best moves two turns ahead for two seconds on all CPUs.
+// The source shuffles the unseen tiles.
+func NewSimulationStrategy(generator game.MoveGenerator, source rand.Source) *SimulationStrategy {
+	return &SimulationStrategy{
+		Candidates: 10,
+		Plies:      2,
+		Budget:     2 * time.Second,
+		Workers:    runtime.NumCPU(),
+		static:     NewStaticStrategy(generator, Expert, source),
+		random:     rand.New(source),
+	}
+}
+
+func (strategy *SimulationStrategy) Decide(myGame *game.Game, player *game.Player) game.Decision {
+	return strategy.Snapshot(myGame, player)()
+}
+
+// Snapshot implements game.SnapshotStrategy, so the game is not held while the position is simulated or the end game is
+// solved
+func (strategy *SimulationStrategy) Snapshot(myGame *game.Game, player *game.Player) func() game.Decision {
+	position := NewPosition(myGame, player)
+	// Once the bag is empty, there is nothing left to simulate and the end game is solved instead
+	var endgame *game.Endgame
+	if position.BagSize == 0 {
+		endgame, _ = myGame.Endgame()
+	}
+	return func() game.Decision {
+		return strategy.decide(position, endgame)
+	}
+}
+
+// decide returns the decision for the position, the end game is solved if there is one
+func (strategy *SimulationStrategy) decide(position *Position, endgame *game.Endgame) game.Decision {
+	if endgame != nil {
+		if solution := endgame.Solve(strategy.static.generator, strategy.Budget); len(solution.Turns) > 0 {
+			return solution.Turns[0].Decision
+		}
+	}
+	simulations := strategy.Analyze(position)
+	// Without moves or with a bad rack, the player exchanges or passes like the expert
+	best := make([]Candidate, 0, 1)
+	if len(simulations) > 0 {
+		best = append(best, simulations[0].Candidate)
+	}
+	exchange := strategy.static.exchange(position.Rack, position.BagSize, position.Board.Rules, best)
+	if len(exchange) > 0 {
+		return game.Decision{Exchange: exchange}
+	}
+	if len(simulations) == 0 {
+		return game.Decision{}
+	}
+	return game.Decision{Move: simulations[0].Candidate.Placement.Move}
+}
+
+// Analyze simulates the best moves of the position until the budget is spent and returns them sorted by descending
+// spread
+func (strategy *SimulationStrategy) Analyze(position *Position) []Simulation {
+	candidates := strategy.static.RankRack(position.Board, position.Rack, position.BagSize)
+	if len(candidates) > strategy.Candidates {
+		candidates = candidates[:strategy.Candidates]
+	}
+	simulations := make([]Simulation, len(candidates))
+	for i, candidate := range candidates {
+		simulations[i].Candidate = candidate
+	}
+	// Without unseen tiles, all simulations would be the same
+	if len(candidates) < 2 || len(position.Unseen) == 0 {
+		return simulations
+	}
+	deadline := time.Now().Add(strategy.Budget)
+	var next int64
+	var mutex sync.Mutex
+	var workers sync.WaitGroup
+	for i := 0; i < strategy.Workers; i++ {
+		random := rand.New(rand.NewSource(strategy.random.Int63()))
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for time.Now().Before(deadline) {
+				iteration := int(atomic.AddInt64(&next, 1) - 1)
+				if strategy.Iterations > 0 && iteration >= strategy.Iterations*len(candidates) {
+					return
+				}
+				index := iteration % len(candidates)
+				spread := strategy.simulate(position, candidates[index], random)
+				mutex.Lock()
+				simulation := &simulations[index]
+				simulation.Iterations++
+				simulation.Spread += (spread - simulation.Spread) / float64(simulation.Iterations)
+				mutex.Unlock()
+			}
+		}()
+	}
+	workers.Wait()
+	sort.SliceStable(simulations, func(a, b int) bool {
+		return simulations[a].Spread > simulations[b].Spread
+	})
+	return simulations
+}
+
+// simulate plays the candidate and the following turns with the unseen tiles shuffled and returns the spread
+func (strategy *SimulationStrategy) simulate(position *Position, candidate Candidate, random *rand.Rand) float64 {
+	board := position.Board.Copy()
+	rackSize := board.Rules.RackSize
+	unseen := append([]game.Tile{}, position.Unseen...)
+	random.Shuffle(len(unseen), func(i, j int) {
+		unseen[i], unseen[j] = unseen[j], unseen[i]
+	})
+	// The opponents hold the unseen tiles which are not in the bag
+	racks := make([][]game.Tile, position.Opponents+1)
+	if position.Opponents > 0 {
+		count := (len(unseen) - position.BagSize) / position.Opponents
+		for i := 1; i < len(racks); i++ {
+			racks[i], unseen = unseen[:count], unseen[count:]
+		}
+	}
+	bag := unseen
+	draw := func(rack []game.Tile) []game.Tile {
+		count := rackSize - len(rack)
+		if count > len(bag) {
+			count = len(bag)
+		}
+		rack = append(rack, bag[:count]...)
+		bag = bag[count:]
+		return rack
+	}
+
+	play(board, candidate.Placement.Move)
+	spread := float64(candidate.Placement.Score.Total)
+	leave := candidate.Leave
+	racks[0] = draw(append([]game.Tile{}, leave...))
+	wentOut := len(racks[0]) == 0
+	for ply, turn := 0, 1%len(racks); ply < strategy.Plies && !wentOut; ply++ {
+		candidates := strategy.static.RankRack(board, racks[turn], len(bag))
+		if len(candidates) > 0 {
+			best := candidates[0]
+			play(board, best.Placement.Move)
+			if turn == 0 {
+				spread += float64(best.Placement.Score.Total)
+				leave = best.Leave
+			} else {
+				spread -= float64(best.Placement.Score.Total)
+			}
+			racks[turn] = draw(append([]game.Tile{}, best.Leave...))
+			wentOut = len(racks[turn]) == 0
+		}
+		turn = (turn + 1) % len(racks)
+	}
+	// The tiles kept by the player are worth something as long as the game goes on
+	if len(bag) > 0 {
+		spread += strategy.static.Weights.Value(leave)
+	}
+	return spread
+}
+
+// play places the tiles of the move on the board
+func play(board *game.Board, move []game.Move) {
+	for _, m := range move {
+		board.PlaceTile(m.Tile, m.X, m.Y)
+	}
+}
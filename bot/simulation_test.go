@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"game"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSimulationStrategy(t *testing.T) {
+	generator := newTestGenerator(t)
+
+	// newTestStrategy creates a strategy with a fixed number of simulations
+	newTestStrategy := func() *SimulationStrategy {
+		strategy := NewSimulationStrategy(generator, rand.NewSource(1))
+		strategy.Budget = time.Minute
+		strategy.Iterations = 20
+		strategy.Workers = 4
+		return strategy
+	}
+
+	t.Run("Positions hide the racks of the opponents in the unseen tiles", func(t *testing.T) {
+		player, opponent := game.NewPlayer("Bot"), game.NewPlayer("Opponent")
+		myGame := newTestGame(t, player, opponent)
+		position := NewPosition(myGame, player)
+		assert.Equal(t, player.Tiles, position.Rack)
+		assert.Equal(t, len(myGame.Bag.Tiles), position.BagSize)
+		assert.Len(t, position.Unseen, len(myGame.Bag.Tiles)+len(opponent.Tiles))
+		assert.Equal(t, 1, position.Opponents)
+		// The snapshot is not changed by the game
+		myGame.Board.PlaceTile(&player.Tiles[0], 7, 7)
+		assert.True(t, position.Board.IsEmpty())
+	})
+
+	t.Run("Candidates are simulated", func(t *testing.T) {
+		strategy := newTestStrategy()
+		player, opponent := game.NewPlayer("Bot"), game.NewPlayer("Opponent")
+		myGame := newTestGame(t, player, opponent)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S", "T", "X")
+		simulations := strategy.Analyze(NewPosition(myGame, player))
+		assert.Len(t, simulations, strategy.Candidates)
+		for i, simulation := range simulations {
+			assert.Equal(t, strategy.Iterations, simulation.Iterations)
+			if i > 0 {
+				assert.GreaterOrEqual(t, simulations[i-1].Spread, simulation.Spread)
+			}
+		}
+		decision := strategy.Decide(myGame, player)
+		assert.True(t, myGame.CheckMove(player, decision.Move).IsValid)
+	})
+
+	t.Run("The budget limits the simulation", func(t *testing.T) {
+		strategy := newTestStrategy()
+		strategy.Iterations = 0
+		strategy.Budget = 100 * time.Millisecond
+		player, opponent := game.NewPlayer("Bot"), game.NewPlayer("Opponent")
+		myGame := newTestGame(t, player, opponent)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S", "T", "X")
+		start := time.Now()
+		simulations := strategy.Analyze(NewPosition(myGame, player))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.NotZero(t, simulations[0].Iterations)
+	})
+
+	t.Run("Going out ends the simulation", func(t *testing.T) {
+		strategy := newTestStrategy()
+		player, opponent := game.NewPlayer("Bot"), game.NewPlayer("Opponent")
+		myGame := newTestGame(t, player, opponent)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S")
+		opponent.Tiles = newTestRack("R", "A", "T", "E")
+		myGame.Bag.Tiles = nil
+		simulations := strategy.Analyze(NewPosition(myGame, player))
+		assert.NotEmpty(t, simulations)
+		// Playing all tiles ends the game, so the opponent scores nothing afterwards
+		for _, simulation := range simulations {
+			if len(simulation.Candidate.Leave) == 0 {
+				assert.Equal(t, float64(simulation.Candidate.Placement.Score.Total), simulation.Spread)
+			}
+		}
+	})
+
+	t.Run("The game is not held while deciding", func(t *testing.T) {
+		strategy := newTestStrategy()
+		strategy.Iterations = 0
+		strategy.Budget = time.Second
+		player, opponent := game.NewPlayer("Bot"), game.NewPlayer("Opponent")
+		myGame := newTestGame(t, player, opponent)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S", "T", "X")
+		current := myGame.CurrentPlayer
+		var decide func() game.Decision
+		myGame.View(func(myGame *game.Game) {
+			decide = strategy.Snapshot(myGame, player)
+		})
+		decided := make(chan game.Decision, 1)
+		go func() {
+			decided <- decide()
+		}()
+		start := time.Now()
+		assert.NoError(t, myGame.Execute(game.PassCommand{Player: current}))
+		assert.Less(t, time.Since(start), strategy.Budget/2)
+		assert.NotEmpty(t, (<-decided).Move)
+	})
+
+	t.Run("The end game is solved", func(t *testing.T) {
+		strategy := newTestStrategy()
+		player, opponent := game.NewPlayer("Bot"), game.NewPlayer("Opponent")
+		myGame := newTestGame(t, player, opponent)
+		myGame.Bag.Tiles = nil
+		myGame.CurrentPlayer = player
+		player.Tiles = newTestRack("C", "A", "R", "E", "S")
+		opponent.Tiles = newTestRack("Q", "Z")
+		decision := strategy.Decide(myGame, player)
+		solution, err := myGame.SolveEndgame(strategy.static.generator, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, solution.Complete)
+		assert.Equal(t, solution.Turns[0].Decision, decision)
+		// Playing "CAR" and then "ES" to go out scores more than playing "CARES" at once
+		assert.Len(t, decision.Move, 3)
+	})
+
+	t.Run("Simulation bots play a game", func(t *testing.T) {
+		strategy := newTestStrategy()
+		strategy.Iterations = 2
+		players := []*game.Player{
+			game.NewBotPlayer("Simulation", strategy),
+			game.NewBotPlayer("Expert", NewStaticStrategy(generator, Expert, rand.NewSource(2))),
+		}
+		myGame, err := game.NewGame(game.WithLexicon(game.NewWordList("test", testWords)), game.WithSeed(1))
+		assert.NoError(t, err)
+		for _, player := range players {
+			assert.NoError(t, myGame.AddPlayer(player))
+		}
+		ended := make(chan game.Standings, 1)
+		unsubscribe := myGame.Subscribe(func(event game.Event) {
+			if event, ok := event.(game.GameEnded); ok {
+				ended <- event.Standings
+			}
+		})
+		defer unsubscribe()
+		stop := myGame.RunBots()
+		defer stop()
+		assert.NoError(t, myGame.Execute(game.StartCommand{}))
+		select {
+		case standings := <-ended:
+			assert.Len(t, standings.Players, 2)
+		case <-time.After(20 * time.Second):
+			t.Fatal("game did not end")
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package bot
+
+// This represents the static evaluation strategy. All moves of the rack are ranked by their equity, which is the score
+// of the move plus the value of its leave. Lower levels ignore the leave and pick among the weaker moves on purpose.
+
+import (
+	"errors"
+	"fmt"
+	"game"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+type Level int
+
+const (
+	Beginner     Level = iota // Picks an average move without caring about the leave
+	Intermediate              // Picks one of the best moves
+	Expert                    // Picks the best move and exchanges bad racks
+)
+
+func (level Level) String() string {
+	switch level {
+	case Beginner:
+		return "beginner"
+	case Intermediate:
+		return "intermediate"
+	case Expert:
+		return "expert"
+	}
+	return fmt.Sprintf("level %d", int(level))
+}
+
+var ErrUnknownLevel = errors.New("unknown level")
+
+// ParseLevel returns the level with the given name
+func ParseLevel(name string) (Level, error) {
+	for _, level := range []Level{Beginner, Intermediate, Expert} {
+		if strings.EqualFold(name, level.String()) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("%w '%s'", ErrUnknownLevel, name)
+}
+
+// levelSettings make the strategy play at a level
+type levelSettings struct {
+	// leaveWeight scales the value of the leave in the equity
+	leaveWeight float64
+	// from and to are the ranks the move is picked from at random as shares of all moves
+	from float64
+	to   float64
+	// exchange allows exchanging tiles instead of playing a move with less equity
+	exchange bool
+}
+
+var levels = map[Level]levelSettings{
+	Beginner:     {leaveWeight: 0, from: 0.3, to: 0.7},
+	Intermediate: {leaveWeight: 0.5, from: 0, to: 0.1},
+	Expert:       {leaveWeight: 1, from: 0, to: 0, exchange: true},
+}
+
+// Candidate is a move ranked by its equity
+type Candidate struct {
+	Placement game.Placement
+	// Leave holds the tiles left on the rack after the move
+	Leave  []game.Tile
+	Equity float64
+}
+
+// StaticStrategy implements game.Strategy by ranking the moves of the rack
+type StaticStrategy struct {
+	Level   Level
+	Weights LeaveWeights
+	// generator enumerates the moves of a rack
+	generator game.MoveGenerator
+	random    *rand.Rand
+}
+
+// NewStaticStrategy creates a strategy playing at the level. The source decides between moves of the same rank.
+func NewStaticStrategy(generator game.MoveGenerator, level Level, source rand.Source) *StaticStrategy {
+	return &StaticStrategy{
+		Level:     level,
+		Weights:   DefaultLeaveWeights(),
+		generator: generator,
+		random:    rand.New(source),
+	}
+}
+
+// Rank returns the moves of the rack of the player sorted by descending equity
+func (strategy *StaticStrategy) Rank(myGame *game.Game, player *game.Player) []Candidate {
+	return strategy.RankRack(myGame.Board, player.Tiles, len(myGame.Bag.Tiles))
+}
+
+// RankRack returns the moves of the rack on the board sorted by descending equity. The leave is worthless once the bag
+// is empty, as no tiles are drawn anymore.
+func (strategy *StaticStrategy) RankRack(board *game.Board, rack []game.Tile, bagSize int) []Candidate {
+	leaveWeight := levels[strategy.Level].leaveWeight
+	if bagSize == 0 {
+		leaveWeight = 0
+	}
+	placements := strategy.generator.GenerateMoves(board, rack)
+	candidates := make([]Candidate, len(placements))
+	for i, placement := range placements {
+		left := leave(rack, placement.Move)
+		candidates[i] = Candidate{
+			Placement: placement,
+			Leave:     left,
+			Equity:    float64(placement.Score.Total) + leaveWeight*strategy.Weights.Value(left),
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].Equity > candidates[b].Equity
+	})
+	return candidates
+}
+
+func (strategy *StaticStrategy) Decide(myGame *game.Game, player *game.Player) game.Decision {
+	settings := levels[strategy.Level]
+	candidates := strategy.Rank(myGame, player)
+	if settings.exchange || len(candidates) == 0 {
+		exchange := strategy.exchange(player.Tiles, len(myGame.Bag.Tiles), myGame.Rules, candidates)
+		if len(exchange) > 0 {
+			return game.Decision{Exchange: exchange}
+		}
+	}
+	if len(candidates) == 0 {
+		return game.Decision{}
+	}
+	from := int(settings.from * float64(len(candidates)))
+	to := int(settings.to * float64(len(candidates)))
+	if to >= len(candidates) {
+		to = len(candidates) - 1
+	}
+	if to < from {
+		to = from
+	}
+	return game.Decision{Move: candidates[from+strategy.random.Intn(to-from+1)].Placement.Move}
+}
+
+// exchange returns the tiles of the rack to exchange if keeping the keepers is worth more than the best move, none
+// otherwise
+func (strategy *StaticStrategy) exchange(rack []game.Tile, bagSize int, rules *game.RuleSet,
+	candidates []Candidate) []game.Tile {
+	if bagSize < rules.ExchangeMinimum {
+		return nil
+	}
+	keep := strategy.Weights.Keep(rack)
+	if len(keep) == len(rack) {
+		return nil
+	}
+	if len(candidates) > 0 && candidates[0].Equity >= strategy.Weights.Value(keep) {
+		return nil
+	}
+	kept := make(map[int]bool, len(keep))
+	for _, tile := range keep {
+		kept[tile.ID] = true
+	}
+	exchange := make([]game.Tile, 0, len(rack)-len(keep))
+	for _, tile := range rack {
+		if !kept[tile.ID] {
+			exchange = append(exchange, tile)
+		}
+	}
+	return exchange
+}
@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"game"
+	"github.com/stretchr/testify/assert"
+	"linguistic"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+var testWords = []string{"care", "cares", "car", "cars", "scare", "scar", "race", "races", "acre", "arc", "arcs", "ace",
+	"aces", "sea", "ear", "ears", "era", "eras", "as", "re", "ae", "ra", "at", "tea", "eat", "rate", "rates", "tear"}
+
+// newTestGenerator creates a move generator for the test words
+func newTestGenerator(t *testing.T) game.MoveGenerator {
+	gaddag, err := linguistic.NewGaddag("test", testWords, game.EnglishTileSet())
+	assert.NoError(t, err)
+	return linguistic.NewMoveGenerator(gaddag)
+}
+
+// newTestGame creates a started game of the players validating against the test words
+func newTestGame(t *testing.T, players ...*game.Player) *game.Game {
+	myGame, err := game.NewGame(game.WithLexicon(game.NewWordList("test", testWords)), game.WithSeed(1))
+	assert.NoError(t, err)
+	for _, player := range players {
+		assert.NoError(t, myGame.AddPlayer(player))
+	}
+	assert.NoError(t, myGame.Start())
+	return myGame
+}
+
+func TestStaticStrategy(t *testing.T) {
+	generator := newTestGenerator(t)
+
+	t.Run("Levels are parsed", func(t *testing.T) {
+		level, err := ParseLevel("Expert")
+		assert.NoError(t, err)
+		assert.Equal(t, Expert, level)
+		_, err = ParseLevel("grandmaster")
+		assert.ErrorIs(t, err, ErrUnknownLevel)
+	})
+
+	t.Run("Moves are ranked by score and leave", func(t *testing.T) {
+		strategy := NewStaticStrategy(generator, Expert, rand.NewSource(1))
+		player := game.NewPlayer("Bot")
+		myGame := newTestGame(t, player)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S", "S", "T")
+		candidates := strategy.Rank(myGame, player)
+		assert.NotEmpty(t, candidates)
+		for i, candidate := range candidates {
+			assert.Equal(t, float64(candidate.Placement.Score.Total)+strategy.Weights.Value(candidate.Leave),
+				candidate.Equity)
+			if i > 0 {
+				assert.GreaterOrEqual(t, candidates[i-1].Equity, candidate.Equity)
+			}
+		}
+		// Keeping an S is worth more than playing both
+		letters := make([]string, 0)
+		for _, tile := range candidates[0].Leave {
+			letters = append(letters, tile.Letter)
+		}
+		assert.Contains(t, letters, "S")
+	})
+
+	t.Run("The expert plays the best move", func(t *testing.T) {
+		strategy := NewStaticStrategy(generator, Expert, rand.NewSource(1))
+		player := game.NewPlayer("Bot")
+		myGame := newTestGame(t, player)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S", "T", "X")
+		decision := strategy.Decide(myGame, player)
+		assert.Equal(t, strategy.Rank(myGame, player)[0].Placement.Move, decision.Move)
+		assert.True(t, myGame.CheckMove(player, decision.Move).IsValid)
+	})
+
+	t.Run("The beginner plays weaker moves", func(t *testing.T) {
+		strategy := NewStaticStrategy(generator, Beginner, rand.NewSource(1))
+		expert := NewStaticStrategy(generator, Expert, rand.NewSource(1))
+		player := game.NewPlayer("Bot")
+		myGame := newTestGame(t, player)
+		player.Tiles = newTestRack("C", "A", "R", "E", "S", "T", "X")
+		best := expert.Rank(myGame, player)[0].Placement.Score.Total
+		for i := 0; i < 10; i++ {
+			decision := strategy.Decide(myGame, player)
+			assert.True(t, myGame.CheckMove(player, decision.Move).IsValid)
+			assert.Less(t, myGame.Board.ScoreMove(decision.Move).Total, best)
+		}
+	})
+
+	t.Run("Racks without moves are exchanged", func(t *testing.T) {
+		strategy := NewStaticStrategy(generator, Beginner, rand.NewSource(1))
+		player := game.NewPlayer("Bot")
+		myGame := newTestGame(t, player)
+		player.Tiles = newTestRack("Q", "S", "V", "V", "W", "X", "Z")
+		decision := strategy.Decide(myGame, player)
+		assert.Empty(t, decision.Move)
+		assert.Equal(t, append([]game.Tile{player.Tiles[0]}, player.Tiles[2:]...), decision.Exchange)
+
+		// Tiles cannot be exchanged if the bag is almost empty
+		myGame.Bag.Tiles = myGame.Bag.Tiles[:3]
+		assert.Equal(t, game.Decision{}, strategy.Decide(myGame, player))
+	})
+
+	t.Run("Bots play a game", func(t *testing.T) {
+		players := []*game.Player{
+			game.NewBotPlayer("Beginner", NewStaticStrategy(generator, Beginner, rand.NewSource(1))),
+			game.NewBotPlayer("Expert", NewStaticStrategy(generator, Expert, rand.NewSource(2))),
+		}
+		myGame, err := game.NewGame(game.WithLexicon(game.NewWordList("test", testWords)), game.WithSeed(1))
+		assert.NoError(t, err)
+		for _, player := range players {
+			assert.NoError(t, myGame.AddPlayer(player))
+		}
+		ended := make(chan game.Standings, 1)
+		unsubscribe := myGame.Subscribe(func(event game.Event) {
+			if event, ok := event.(game.GameEnded); ok {
+				ended <- event.Standings
+			}
+		})
+		defer unsubscribe()
+		stop := myGame.RunBots()
+		defer stop()
+		assert.NoError(t, myGame.Execute(game.StartCommand{}))
+		select {
+		case standings := <-ended:
+			assert.Len(t, standings.Players, 2)
+		case <-time.After(10 * time.Second):
+			t.Fatal("game did not end")
+		}
+		myGame.View(func(myGame *game.Game) {
+			assert.NotEmpty(t, myGame.History)
+		})
+	})
+}
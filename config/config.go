@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"github.com/spf13/viper"
 	"log"
+	"sort"
+	"strings"
 )
 
 // read config file using viper
@@ -15,6 +19,8 @@ const (
 	DW        // DoubleWordField
 	TW        // TripleWordField
 	CS        // CenterStarField
+	QL        // QuadrupleLetterField
+	QW        // QuadrupleWordField
 	// Special field type colors:
 	NFColor = 0xffffff // White
 	DLColor = 0x0000ff // Light blue
@@ -22,6 +28,8 @@ const (
 	DWColor = 0xff00ff // Pink
 	TWColor = 0xff0000 // Red
 	CSColor = 0x101010
+	QLColor = 0x008000 // Green
+	QWColor = 0x800000 // Dark red
 )
 
 // Special field matrix:
@@ -110,6 +118,8 @@ type Theme struct {
 	DWColor int
 	TWColor int
 	CSColor int
+	QLColor int
+	QWColor int
 	// more to come ...
 }
 
@@ -132,6 +142,8 @@ func NewConfig() *Config {
 			DWColor: DWColor,
 			TWColor: TWColor,
 			CSColor: CSColor,
+			QLColor: QLColor,
+			QWColor: QWColor,
 		},
 	}
 }
@@ -152,24 +164,90 @@ func (c *Config) GetTheme() Theme {
 	return c.Theme
 }
 
-// ReadConfig reads the config file and returns a Config struct using viper
-func ReadConfig() *Config {
-	viper.SetConfigName("config")
-	viper.AddConfigPath(".")
-	viper.SetConfigType("json")
-	viper.AutomaticEnv()
+var (
+	ErrInvalidDimensions  = errors.New("special fields are not a square matrix")
+	ErrUnknownFieldType   = errors.New("unknown field type")
+	ErrNoCenterStar       = errors.New("special fields have no center star")
+	ErrMissingLetterScore = errors.New("letter has a distribution but no score")
+	ErrNoTiles            = errors.New("tile distribution is empty")
+)
+
+// ReadConfig reads the file config.json in the working directory, see ReadConfigFile
+func ReadConfig() (*Config, error) {
+	return ReadConfigFile("config.json")
+}
+
+// ReadConfigFile reads the config file at the given path and returns a Config struct using viper. The config is
+// validated, missing theme colors are taken from the default config.
+func ReadConfigFile(path string) (*Config, error) {
+	reader := viper.New()
+	reader.SetConfigFile(path)
+	reader.SetConfigType("json")
+	reader.AutomaticEnv()
 
-	if err := viper.ReadInConfig(); err != nil {
-		panic(err)
+	if err := reader.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", path, err)
 	}
 
-	var config Config
-	err := viper.Unmarshal(&config)
-	if err != nil {
-		panic(err)
+	config := &Config{Theme: NewConfig().Theme}
+	if err := reader.Unmarshal(config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file '%s': %w", path, err)
 	}
+	// Viper lower cases all keys, but letters are upper case
+	config.LetterScores = upperCaseKeys(config.LetterScores)
+	config.TileDist = upperCaseKeys(config.TileDist)
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file '%s': %w", path, err)
+	}
+	return config, nil
+}
 
-	return &config
+func upperCaseKeys(values map[string]int) map[string]int {
+	result := make(map[string]int, len(values))
+	for key, value := range values {
+		result[strings.ToUpper(key)] = value
+	}
+	return result
+}
+
+// Validate checks that the special fields form a square matrix of known field types with a center star and that every
+// letter of the tile distribution has a score. All problems found are joined into the returned error.
+func (c *Config) Validate() error {
+	problems := make([]error, 0)
+	size := len(c.SpecialFields)
+	if size == 0 {
+		problems = append(problems, fmt.Errorf("%w: no rows", ErrInvalidDimensions))
+	}
+	hasCenterStar := false
+	for x, row := range c.SpecialFields {
+		if len(row) != size {
+			problems = append(problems, fmt.Errorf("%w: row %d has %d fields instead of %d",
+				ErrInvalidDimensions, x, len(row), size))
+		}
+		for y, fieldType := range row {
+			if fieldType < NF || fieldType > QW {
+				problems = append(problems, fmt.Errorf("%w %d at (%d, %d)", ErrUnknownFieldType, fieldType, x, y))
+			}
+			hasCenterStar = hasCenterStar || fieldType == CS
+		}
+	}
+	if size > 0 && !hasCenterStar {
+		problems = append(problems, ErrNoCenterStar)
+	}
+	if len(c.TileDist) == 0 {
+		problems = append(problems, ErrNoTiles)
+	}
+	letters := make([]string, 0, len(c.TileDist))
+	for letter := range c.TileDist {
+		letters = append(letters, letter)
+	}
+	sort.Strings(letters)
+	for _, letter := range letters {
+		if _, ok := c.LetterScores[letter]; !ok {
+			problems = append(problems, fmt.Errorf("%w: '%s'", ErrMissingLetterScore, letter))
+		}
+	}
+	return errors.Join(problems...)
 }
 
 // WriteConfig writes the config file using viper
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfigFile writes the content to a config file in a temporary directory and returns its path
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestReadConfigFile(t *testing.T) {
+	t.Run("Shipped config is valid", func(t *testing.T) {
+		config, err := ReadConfigFile("../main/config.json")
+		assert.NoError(t, err)
+		assert.Equal(t, NewConfig().SpecialFields, config.SpecialFields)
+		assert.Equal(t, NewConfig().LetterScores, config.LetterScores)
+		assert.Equal(t, NewConfig().TileDist, config.TileDist)
+		assert.Equal(t, NewConfig().Theme, config.Theme)
+	})
+
+	t.Run("Letters are upper case and missing colors are defaults", func(t *testing.T) {
+		config, err := ReadConfigFile(writeConfigFile(t, `{
+			"SpecialFields": [[0, 0, 0], [0, 5, 0], [0, 0, 0]],
+			"LetterScores": {"A": 1, "CH": 5},
+			"TileDist": {"A": 5, "CH": 1},
+			"Theme": {"CSColor": 255}
+		}`))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"A": 1, "CH": 5}, config.LetterScores)
+		assert.Equal(t, map[string]int{"A": 5, "CH": 1}, config.TileDist)
+		assert.Equal(t, 255, config.Theme.CSColor)
+		assert.Equal(t, NFColor, config.Theme.NFColor)
+	})
+
+	t.Run("Missing file is an error", func(t *testing.T) {
+		_, err := ReadConfigFile(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid config is rejected with all problems", func(t *testing.T) {
+		_, err := ReadConfigFile(writeConfigFile(t, `{
+			"SpecialFields": [[0, 0, 0], [0, 9, 0], [0, 0]],
+			"LetterScores": {"A": 1},
+			"TileDist": {"A": 5, "B": 2}
+		}`))
+		assert.ErrorIs(t, err, ErrInvalidDimensions)
+		assert.ErrorIs(t, err, ErrUnknownFieldType)
+		assert.ErrorIs(t, err, ErrNoCenterStar)
+		assert.ErrorIs(t, err, ErrMissingLetterScore)
+		assert.NotErrorIs(t, err, ErrNoTiles)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Default config is valid", func(t *testing.T) {
+		assert.NoError(t, NewConfig().Validate())
+	})
+
+	t.Run("Empty config is invalid", func(t *testing.T) {
+		err := (&Config{}).Validate()
+		assert.ErrorIs(t, err, ErrInvalidDimensions)
+		assert.ErrorIs(t, err, ErrNoTiles)
+	})
+}
@@ -2,28 +2,57 @@ package game
 
 import (
 	"math/rand"
+	"sort"
 	"time"
 )
 
 type Bag struct {
 	Tiles []Tile
+	// Seed of the random source tiles are drawn with. A bag created with the same seed yields the same sequence of
+	// draws. It is zero for bags created from a custom source.
+	Seed   int64
+	source *countingSource
+	random *rand.Rand
+	// seeded is set if the source was created from the seed, so its state can be restored by drawing again
+	seeded bool
+}
+
+// countingSource counts the values drawn from a random source, so a seeded source can be brought back to the same state
+// by drawing the same number of values again
+type countingSource struct {
+	rand.Source
+	count int64
+}
+
+func (source *countingSource) Int63() int64 {
+	source.count++
+	return source.Source.Int63()
+}
+
+func (source *countingSource) Seed(seed int64) {
+	source.Source.Seed(seed)
+	source.count = 0
 }
 
 type BagActions interface {
 	// TakeTiles takes a number of random tiles from the bag and returns them. If the bag is empty, it returns an empty slice
 	// of tiles. If count exceeds the number of tiles in the bag, it returns all tiles in the bag.
 	TakeTiles(count int) []Tile
+	// ReturnTiles puts the given tiles back into the bag
+	ReturnTiles(tiles []Tile)
+	// ExchangeTiles draws as many tiles as given and puts the given tiles back into the bag afterward, so a player never
+	// draws the tiles just returned
+	ExchangeTiles(tiles []Tile) []Tile
 }
 
 func (bag *Bag) TakeTiles(count int) []Tile {
-	rand.Seed(time.Now().UnixNano())
 	if count > len(bag.Tiles) {
 		count = len(bag.Tiles)
 	}
 	tiles := make([]Tile, count)
 	// take random tiles from the bag
 	for i := 0; i < count; i++ {
-		randomIndex := rand.Intn(len(bag.Tiles))
+		randomIndex := bag.random.Intn(len(bag.Tiles))
 		tiles[i] = bag.Tiles[randomIndex]
 		// remove the tile from the bag
 		bag.Tiles = append(bag.Tiles[:randomIndex], bag.Tiles[randomIndex+1:]...)
@@ -31,13 +60,75 @@ func (bag *Bag) TakeTiles(count int) []Tile {
 	return tiles
 }
 
+func (bag *Bag) ReturnTiles(tiles []Tile) {
+	bag.Tiles = append(bag.Tiles, tiles...)
+}
+
+func (bag *Bag) ExchangeTiles(tiles []Tile) []Tile {
+	newTiles := bag.TakeTiles(len(tiles))
+	bag.ReturnTiles(tiles)
+	return newTiles
+}
+
+// draws returns the number of random values drawn from the source of the bag so far
+func (bag *Bag) draws() int64 {
+	return bag.source.count
+}
+
+// rewind brings the source of the bag back to its state after the number of draws, e.g. when a turn is taken back, so
+// the following draws are the same as in a replay from the seed. Bags drawing from a custom source cannot be rewound
+// and keep their state.
+func (bag *Bag) rewind(draws int64) {
+	if !bag.seeded || draws == bag.source.count {
+		return
+	}
+	bag.source.Seed(bag.Seed)
+	for bag.source.count < draws {
+		bag.source.Int63()
+	}
+}
+
+// NewBag creates a bag drawing tiles with a random seed
 func NewBag() *Bag {
+	return NewBagWithSeed(time.Now().UnixNano())
+}
+
+// NewBagWithSeed creates a bag drawing tiles with the given seed, e.g. to replay a game
+func NewBagWithSeed(seed int64) *Bag {
+	return newBagWithSeed(OfficialRules(), seed)
+}
+
+// newBagWithSeed creates a bag with the tiles of the rules drawing tiles with the given seed
+func newBagWithSeed(rules *RuleSet, seed int64) *Bag {
+	bag := NewBagWithRuleSet(rules, rand.NewSource(seed))
+	bag.Seed = seed
+	bag.seeded = true
+	return bag
+}
+
+// NewBagWithSource creates a bag with the tiles of the official rules drawing tiles from the given random source
+func NewBagWithSource(source rand.Source) *Bag {
+	return NewBagWithRuleSet(OfficialRules(), source)
+}
+
+// NewBagWithRuleSet creates a bag with the tile distribution and letter scores of the rules drawing tiles from the given
+// random source
+func NewBagWithRuleSet(rules *RuleSet, source rand.Source) *Bag {
+	counting := &countingSource{Source: source}
 	bag := &Bag{
-		Tiles: make([]Tile, 0),
+		Tiles:  make([]Tile, 0),
+		source: counting,
+		random: rand.New(counting),
+	}
+	// Iterate the letters in a fixed order, so every bag assigns the same IDs to the same letters
+	letters := make([]string, 0, len(rules.TileDistribution))
+	for letter := range rules.TileDistribution {
+		letters = append(letters, letter)
 	}
-	for letter, count := range tileDistribution {
-		for i := 0; i < count; i++ {
-			bag.Tiles = append(bag.Tiles, *NewTile(letter, LetterScores[letter]))
+	sort.Strings(letters)
+	for _, letter := range letters {
+		for i := 0; i < rules.TileDistribution[letter]; i++ {
+			bag.Tiles = append(bag.Tiles, Tile{ID: len(bag.Tiles) + 1, Letter: letter, LetterScore: rules.LetterScores[letter]})
 		}
 	}
 	return bag
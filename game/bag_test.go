@@ -0,0 +1,53 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestBag(t *testing.T) {
+	t.Run("Bags with the same seed draw the same tiles", func(t *testing.T) {
+		bag := NewBagWithSeed(42)
+		replay := NewBagWithSeed(42)
+		assert.Equal(t, int64(42), replay.Seed)
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, bag.TakeTiles(RackSize), replay.TakeTiles(RackSize))
+		}
+		assert.Empty(t, bag.Tiles)
+		assert.Empty(t, replay.Tiles)
+	})
+
+	t.Run("Bags with different seeds draw different tiles", func(t *testing.T) {
+		assert.NotEqual(t, NewBagWithSeed(1).TakeTiles(RackSize), NewBagWithSeed(2).TakeTiles(RackSize))
+	})
+
+	t.Run("Bag draws from an injected source", func(t *testing.T) {
+		bag := NewBagWithSource(rand.NewSource(7))
+		assert.Equal(t, NewBagWithSeed(7).TakeTiles(RackSize), bag.TakeTiles(RackSize))
+		assert.Zero(t, bag.Seed)
+	})
+
+	t.Run("Exchange is reproducible", func(t *testing.T) {
+		bag := NewBagWithSeed(3)
+		replay := NewBagWithSeed(3)
+		rack := bag.TakeTiles(RackSize)
+		assert.Equal(t, rack, replay.TakeTiles(RackSize))
+		assert.Equal(t, bag.ExchangeTiles(rack[:3]), replay.ExchangeTiles(rack[:3]))
+		assert.Equal(t, bag.Tiles, replay.Tiles)
+	})
+
+	t.Run("Games with the same seed start the same way", func(t *testing.T) {
+		start := func() *Game {
+			myGame := newTestLobby("Alice", "Bob", "Carol")
+			myGame.Bag = NewBagWithSeed(99)
+			assert.NoError(t, myGame.Start())
+			return myGame
+		}
+		myGame, replay := start(), start()
+		assert.Equal(t, myGame.CurrentPlayer.Name, replay.CurrentPlayer.Name)
+		for i := range myGame.Players {
+			assert.Equal(t, myGame.Players[i].Tiles, replay.Players[i].Tiles)
+		}
+	})
+}
@@ -1,105 +1,43 @@
 package game
 
+import "config"
+
 const (
-	// NF Special field types:
-	NF = iota // NormalField
-	DL        // DoubleLetterField
-	TL        // TripleLetterField
-	DW        // DoubleWordField
-	TW        // TripleWordField
-	CS        // CenterStarField
+	// NF Special field types, see package config:
+	NF = config.NF // NormalField
+	DL = config.DL // DoubleLetterField
+	TL = config.TL // TripleLetterField
+	DW = config.DW // DoubleWordField
+	TW = config.TW // TripleWordField
+	CS = config.CS // CenterStarField
+	QL = config.QL // QuadrupleLetterField
+	QW = config.QW // QuadrupleWordField
 	// NFColor Special field type colors:
-	NFColor = 0xffffff // White
-	DLColor = 0x0000ff // Light blue
-	TLColor = 0x000080 // Blue
-	DWColor = 0xff00ff // Pink
-	TWColor = 0xff0000 // Red
-	CSColor = 0x101010
+	NFColor = config.NFColor
+	DLColor = config.DLColor
+	TLColor = config.TLColor
+	DWColor = config.DWColor
+	TWColor = config.TWColor
+	CSColor = config.CSColor
+	QLColor = config.QLColor
+	QWColor = config.QWColor
 )
 
-// Special field matrix:
-var specialFields = [][]int{
-	{TW, NF, NF, DL, NF, NF, NF, TW, NF, NF, NF, DL, NF, NF, TW},
-	{NF, DW, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, DW, NF},
-	{NF, NF, DW, NF, NF, NF, DL, NF, DL, NF, NF, NF, DW, NF, NF},
-	{DL, NF, NF, DW, NF, NF, NF, DL, NF, NF, NF, DW, NF, NF, DL},
-	{NF, NF, NF, NF, DW, NF, NF, NF, NF, NF, DW, NF, NF, NF, NF},
-	{NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF},
-	{NF, NF, DL, NF, NF, NF, DL, NF, DL, NF, NF, NF, DL, NF, NF},
-	{TW, NF, NF, DL, NF, NF, NF, CS, NF, NF, NF, DL, NF, NF, TW},
-	{NF, NF, DL, NF, NF, NF, DL, NF, DL, NF, NF, NF, DL, NF, NF},
-	{NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF},
-	{NF, NF, NF, NF, DW, NF, NF, NF, NF, NF, DW, NF, NF, NF, NF},
-	{DL, NF, NF, DW, NF, NF, NF, DL, NF, NF, NF, DW, NF, NF, DL},
-	{NF, NF, DW, NF, NF, NF, DL, NF, DL, NF, NF, NF, DW, NF, NF},
-	{NF, DW, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, DW, NF},
-	{TW, NF, NF, DL, NF, NF, NF, TW, NF, NF, NF, DL, NF, NF, TW},
-}
-
-var LetterScores = map[string]int{
-	"A": 1,
-	"B": 3,
-	"C": 3,
-	"D": 2,
-	"E": 1,
-	"F": 4,
-	"G": 2,
-	"H": 4,
-	"I": 1,
-	"J": 8,
-	"K": 5,
-	"L": 1,
-	"M": 3,
-	"N": 1,
-	"O": 1,
-	"P": 3,
-	"Q": 10,
-	"R": 1,
-	"S": 1,
-	"T": 1,
-	"U": 1,
-	"V": 4,
-	"W": 4,
-	"X": 8,
-	"Y": 4,
-	"Z": 10,
-	"*": 0,
-}
-
-var tileDistribution = map[string]int{
-	"A": 9,
-	"B": 2,
-	"C": 2,
-	"D": 4,
-	"E": 12,
-	"F": 2,
-	"G": 3,
-	"H": 2,
-	"I": 9,
-	"J": 1,
-	"K": 1,
-	"L": 4,
-	"M": 2,
-	"N": 6,
-	"O": 8,
-	"P": 2,
-	"Q": 1,
-	"R": 6,
-	"S": 4,
-	"T": 6,
-	"U": 4,
-	"V": 2,
-	"W": 2,
-	"X": 1,
-	"Y": 2,
-	"Z": 1,
-	"*": 2,
-}
+// The official rules are taken from the default config
+
+// Special field matrix of the official rules:
+var specialFields = config.NewConfig().SpecialFields
+
+var LetterScores = config.NewConfig().LetterScores
+
+var tileDistribution = config.NewConfig().TileDist
 
 type Board struct {
 	Fields [][]Field
-	// Reverse map of tile positions as pairs of int
-	TilePositions map[Tile][2]int
+	// Reverse map of tile positions as pairs of int by tile ID
+	TilePositions map[int][2]int
+	// Rules the board is set up and scored with
+	Rules *RuleSet
 }
 
 type BoardActions interface {
@@ -113,36 +51,39 @@ type BoardActions interface {
 	IsTileOnBoard(tile *Tile) bool
 	SetTilePosition(tile *Tile, x int, y int)
 	UnsetTilePosition(tile *Tile)
+	// Copy returns a board with the same tiles, which can be changed without changing this board. The tiles are
+	// shared and must not be changed.
+	Copy() *Board
 }
 
 func (r *Board) PlaceTile(tile *Tile, x int, y int) {
 	if tile == nil {
 		return
 	}
-	r.Fields[x][y].Tile = tile
 	// Check if tile was already placed and remove it from the old position
 	if oldPos, ok := r.GetTilePosition(tile); ok {
 		r.RemoveTileByCoordinates(oldPos[0], oldPos[1])
 	}
+	r.Fields[x][y].Tile = tile
 	r.SetTilePosition(tile, x, y)
 }
 
 func (r *Board) GetTilePosition(tile *Tile) ([2]int, bool) {
-	coordinate, exists := r.TilePositions[*tile]
+	coordinate, exists := r.TilePositions[tile.ID]
 	return coordinate, exists
 }
 
 func (r *Board) IsTileOnBoard(tile *Tile) bool {
-	_, exists := r.TilePositions[*tile]
+	_, exists := r.TilePositions[tile.ID]
 	return exists
 }
 
 func (r *Board) SetTilePosition(tile *Tile, x int, y int) {
-	r.TilePositions[*tile] = [2]int{x, y}
+	r.TilePositions[tile.ID] = [2]int{x, y}
 }
 
 func (r *Board) UnsetTilePosition(tile *Tile) {
-	delete(r.TilePositions, *tile)
+	delete(r.TilePositions, tile.ID)
 }
 
 func (r *Board) RemoveTileByReference(tile *Tile) {
@@ -160,7 +101,7 @@ func (r *Board) RemoveTileByCoordinates(x int, y int) {
 }
 
 func (r *Board) GetField(x int, y int) (Field, bool) {
-	if x < 0 || x > 14 || y < 0 || y > 14 {
+	if !r.withinBounds(x, y) {
 		return Field{}, false
 	}
 	return r.Fields[x][y], true
@@ -185,15 +126,43 @@ func (r *Board) IsEmpty() bool {
 	return true
 }
 
+func (r *Board) Copy() *Board {
+	board := &Board{
+		Fields:        make([][]Field, len(r.Fields)),
+		TilePositions: make(map[int][2]int, len(r.TilePositions)),
+		Rules:         r.Rules,
+	}
+	for i := range r.Fields {
+		board.Fields[i] = append([]Field{}, r.Fields[i]...)
+	}
+	for id, position := range r.TilePositions {
+		board.TilePositions[id] = position
+	}
+	return board
+}
+
+// withinBounds checks if the coordinates are on the board
+func (r *Board) withinBounds(x int, y int) bool {
+	return x >= 0 && x < len(r.Fields) && y >= 0 && y < len(r.Fields[x])
+}
+
+// NewBoard creates an empty board of the official rules
 func NewBoard() *Board {
+	return NewBoardWithRuleSet(OfficialRules())
+}
+
+// NewBoardWithRuleSet creates an empty board with the size and premium layout of the rules
+func NewBoardWithRuleSet(rules *RuleSet) *Board {
+	columns, rows := rules.Size()
 	board := &Board{
-		Fields:        make([][]Field, 15),
-		TilePositions: make(map[Tile][2]int),
+		Fields:        make([][]Field, columns),
+		TilePositions: make(map[int][2]int),
+		Rules:         rules,
 	}
 	for i := range board.Fields {
-		board.Fields[i] = make([]Field, 15)
+		board.Fields[i] = make([]Field, rows)
 		for j := range board.Fields[i] {
-			board.Fields[i][j] = *NewField(specialFields[i][j])
+			board.Fields[i][j] = *NewField(rules.SpecialFields[i][j])
 		}
 	}
 	return board
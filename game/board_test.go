@@ -0,0 +1,109 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTileIdentity(t *testing.T) {
+	t.Run("Bag tiles have unique IDs", func(t *testing.T) {
+		bag := NewBag()
+		ids := make(map[int]bool)
+		for _, tile := range bag.Tiles {
+			assert.NotZero(t, tile.ID)
+			assert.False(t, ids[tile.ID], "duplicate ID %d", tile.ID)
+			ids[tile.ID] = true
+		}
+		assert.Equal(t, bag.Tiles, NewBag().Tiles)
+	})
+
+	t.Run("Moving a tile does not clobber a tile with the same letter", func(t *testing.T) {
+		board := NewBoard()
+		first := newTestTile("E", 1)
+		second := newTestTile("E", 1)
+		board.PlaceTile(first, 7, 7)
+		board.PlaceTile(second, 8, 7)
+		board.PlaceTile(first, 7, 8)
+		assert.True(t, board.IsFieldEmpty(7, 7))
+		assert.Same(t, second, board.Fields[8][7].Tile)
+		assert.Same(t, first, board.Fields[7][8].Tile)
+		position, ok := board.GetTilePosition(second)
+		assert.True(t, ok)
+		assert.Equal(t, [2]int{8, 7}, position)
+	})
+
+	t.Run("Tiles which do not come from a bag are told apart", func(t *testing.T) {
+		board := NewBoard()
+		first := NewTile("E", 1)
+		second := NewTile("E", 1)
+		assert.NotEqual(t, first.ID, second.ID)
+		assert.Negative(t, first.ID)
+		board.PlaceTile(first, 7, 7)
+		board.PlaceTile(second, 8, 7)
+		assert.Same(t, first, board.Fields[7][7].Tile)
+		assert.Same(t, second, board.Fields[8][7].Tile)
+	})
+
+	t.Run("Removing a tile keeps a tile with the same letter on the board", func(t *testing.T) {
+		board := NewBoard()
+		first := newTestTile("E", 1)
+		second := newTestTile("E", 1)
+		board.PlaceTile(first, 7, 7)
+		board.PlaceTile(second, 8, 7)
+		board.RemoveTileByReference(first)
+		assert.True(t, board.IsFieldEmpty(7, 7))
+		assert.True(t, board.IsTileOnBoard(second))
+		assert.False(t, board.IsTileOnBoard(first))
+	})
+
+	t.Run("Placing a tile on its own field keeps it on the board", func(t *testing.T) {
+		board := NewBoard()
+		tile := newTestTile("E", 1)
+		board.PlaceTile(tile, 7, 7)
+		board.PlaceTile(tile, 7, 7)
+		assert.Same(t, tile, board.Fields[7][7].Tile)
+	})
+
+	t.Run("Player removes the given tile of two with the same letter", func(t *testing.T) {
+		player := NewPlayer("Alice")
+		first := newTestTile("E", 1)
+		second := newTestTile("E", 1)
+		player.Tiles = []Tile{*first, *second}
+		player.RemoveTile(*second)
+		assert.Equal(t, []Tile{*first}, player.Tiles)
+		assert.False(t, player.HasRackTiles([]Tile{*second}))
+		assert.False(t, player.HasRackTiles([]Tile{*first, *first}))
+	})
+
+	t.Run("Temporary moves of tiles with the same letter are kept apart", func(t *testing.T) {
+		myGame := newTestGame()
+		player := myGame.CurrentPlayer
+		first := Move{X: 7, Y: 7, Tile: newTestTile("E", 1)}
+		second := Move{X: 8, Y: 7, Tile: newTestTile("E", 1)}
+		myGame.AddTemporaryMove(player, first)
+		myGame.AddTemporaryMove(player, second)
+		// Moving the first tile replaces its previous position
+		moved := Move{X: 9, Y: 7, Tile: first.Tile}
+		myGame.AddTemporaryMove(player, moved)
+		assert.Equal(t, []Move{second, moved}, myGame.TemporaryMoves[player])
+		myGame.RemoveTemporaryMove(player, moved)
+		assert.Equal(t, []Move{second}, myGame.TemporaryMoves[player])
+	})
+}
+
+func TestBoardCopy(t *testing.T) {
+	t.Run("Copies are changed independently", func(t *testing.T) {
+		board := NewBoard()
+		first := newTestTile("E", 1)
+		board.PlaceTile(first, 7, 7)
+		copied := board.Copy()
+		second := newTestTile("A", 1)
+		copied.PlaceTile(second, 8, 7)
+		copied.RemoveTileByReference(first)
+		assert.Same(t, first, board.Fields[7][7].Tile)
+		assert.True(t, board.IsFieldEmpty(8, 7))
+		assert.False(t, board.IsTileOnBoard(second))
+		assert.Same(t, second, copied.Fields[8][7].Tile)
+		assert.Same(t, board.Rules, copied.Rules)
+	})
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+)
+
+// This represents computer opponents. A bot is a player whose turns are decided by a strategy. Strategies need to
+// generate moves and live outside of this package, see module bot.
+
+// Decision is what a player does on its turn. The player plays the move, exchanges the tiles or passes if both are
+// empty.
+type Decision struct {
+	Move     []Move
+	Exchange []Tile
+}
+
+func (decision Decision) String() string {
+	if len(decision.Move) > 0 {
+		letters := ""
+		for _, move := range decision.Move {
+			letters += move.Tile.PlayedLetter()
+		}
+		return fmt.Sprintf("play '%s' at (%d, %d)", letters, decision.Move[0].X, decision.Move[0].Y)
+	} else if len(decision.Exchange) > 0 {
+		return fmt.Sprintf("exchange %d tiles", len(decision.Exchange))
+	}
+	return "pass"
+}
+
+// Strategy decides the turns of a computer player
+type Strategy interface {
+	// Decide returns the decision of the player on its turn. It is called while viewing the game, so it must not
+	// change the game.
+	Decide(game *Game, player *Player) Decision
+}
+
+// SnapshotStrategy is a strategy which takes its time to decide. It takes a snapshot of the game while viewing it and
+// decides on the snapshot once the game is released, so the game is not held while the strategy thinks.
+type SnapshotStrategy interface {
+	Strategy
+	// Snapshot is called while viewing the game. The returned function decides the turn without access to the game.
+	Snapshot(game *Game, player *Player) func() Decision
+}
+
+// NewBotPlayer creates a computer player whose turns are decided by the strategy
+func NewBotPlayer(name string, strategy Strategy) *Player {
+	player := NewPlayer(name)
+	player.Strategy = strategy
+	return player
+}
+
+// DecisionCommand carries out the decision of the player. Score and Result are set once a move was played. An invalid
+// move is taken back and rejected with ErrInvalidMove.
+type DecisionCommand struct {
+	Player   *Player
+	Decision Decision
+	Score    MoveScore
+	Result   MoveCheckResult
+}
+
+func (command *DecisionCommand) Apply(game *Game) error {
+	if err := game.CheckTurn(command.Player); err != nil {
+		return err
+	}
+	if len(command.Decision.Move) > 0 {
+		game.ResetTemporaryMoves(command.Player)
+		for _, move := range command.Decision.Move {
+			game.AddTemporaryMove(command.Player, move)
+		}
+		command.Score, command.Result = game.PlayTemporaryMoves(command.Player)
+		if !command.Result.IsValid {
+			game.ResetTemporaryMoves(command.Player)
+			return fmt.Errorf("%w: %s", ErrInvalidMove, command.Result)
+		}
+		return nil
+	} else if len(command.Decision.Exchange) > 0 {
+		return game.ExchangeTiles(command.Player, command.Decision.Exchange)
+	}
+	return game.Pass(command.Player)
+}
+
+type BotActions interface {
+	// RunBots lets the computer players of the game take their turns until the returned function is called. Bots accept
+	// the pending moves of other players.
+	RunBots() (stop func())
+}
+
+func (game *Game) RunBots() func() {
+	stop := game.Subscribe(func(event Event) {
+		switch event := event.(type) {
+		case TurnChanged:
+			if event.Player.IsBot() {
+				game.playBotTurn(event.Player)
+			}
+		case MovePending:
+			for _, player := range game.Players {
+				if player.IsBot() && player != event.Player {
+					if err := game.Execute(AcceptCommand{Player: player}); err != nil {
+						zap.S().Debugf("Bot '%s' cannot accept the move: %s", player.Name, err)
+					}
+					break
+				}
+			}
+		}
+	})
+	// The bot may be the starting player of a game which is already started
+	var current *Player
+	game.View(func(game *Game) {
+		if game.Phase == PhaseInProgress && game.Pending == nil {
+			current = game.CurrentPlayer
+		}
+	})
+	if current != nil && current.IsBot() {
+		go game.playBotTurn(current)
+	}
+	return stop
+}
+
+// playBotTurn lets the strategy of the player decide its turn and carries out the decision. The bot passes if its
+// decision is rejected, so the game does not get stuck. A decision made on a snapshot is dropped if a turn was played or
+// undone meanwhile, as the turn is decided again on the event of the change.
+func (game *Game) playBotTurn(player *Player) {
+	var decision Decision
+	var decide func() Decision
+	isTurn, turns := false, 0
+	game.View(func(game *Game) {
+		if isTurn = game.CheckTurn(player) == nil; isTurn {
+			turns = len(game.History)
+			if strategy, ok := player.Strategy.(SnapshotStrategy); ok {
+				decide = strategy.Snapshot(game, player)
+			} else {
+				decision = player.Strategy.Decide(game, player)
+			}
+		}
+	})
+	if !isTurn {
+		return
+	}
+	if decide != nil {
+		decision = decide()
+		game.View(func(game *Game) {
+			isTurn = game.CheckTurn(player) == nil && len(game.History) == turns
+		})
+		if !isTurn {
+			zap.S().Debugf("Bot '%s' dropped its decision, as the game changed", player.Name)
+			return
+		}
+	}
+	zap.S().Debugf("Bot '%s' decided to %s", player.Name, decision)
+	if err := game.Execute(&DecisionCommand{Player: player, Decision: decision}); err != nil {
+		zap.S().Warnf("Decision of bot '%s' was rejected: %s", player.Name, err)
+		if err := game.Execute(PassCommand{Player: player}); err != nil {
+			zap.S().Debugf("Bot '%s' cannot pass: %s", player.Name, err)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+// This represents challenging moves. Under the void challenge rule a move with a word which is not in the dictionary is
+// rejected right away. Under the single and double challenge rules any word may be played. The move stays pending
+// until another player accepts or challenges it.
+
+type ChallengeRule int
+
+const (
+	VoidChallenge   ChallengeRule = iota // Moves with unknown words are rejected
+	SingleChallenge                      // A phony is withdrawn, a failed challenge costs the penalty of the rules
+	DoubleChallenge                      // A phony is withdrawn, a failed challenge costs the challenger the next turn
+)
+
+func (rule ChallengeRule) String() string {
+	switch rule {
+	case VoidChallenge:
+		return "void"
+	case SingleChallenge:
+		return "single"
+	case DoubleChallenge:
+		return "double"
+	}
+	return fmt.Sprintf("challenge rule %d", int(rule))
+}
+
+// PendingMove is a move placed on the board which is neither accepted nor challenged yet. Its score is credited once
+// it is accepted.
+type PendingMove struct {
+	Player *Player
+	// Move holds the tiles as placed on the board
+	Move   []Move
+	Score  MoveScore
+	Words  []Word
+	result MoveCheckResult
+	before turnState
+}
+
+// ChallengeResult is the outcome of a challenge
+type ChallengeResult struct {
+	// Phony is true if at least one word of the move is not in the dictionary, so the move was withdrawn
+	Phony        bool
+	InvalidWords []Word
+	// Penalty is the number of points the challenger lost
+	Penalty int
+	// LostTurn is true if the challenger forfeits the next turn
+	LostTurn bool
+}
+
+var (
+	ErrMovePending   = errors.New("the last move has to be accepted or challenged first")
+	ErrNoMovePending = errors.New("there is no move to accept or challenge")
+	ErrOwnMove       = errors.New("players cannot accept or challenge their own move")
+)
+
+type ChallengeActions interface {
+	// AcceptMove accepts the pending move on behalf of the player. The move is scored and the turn passes to the next
+	// player.
+	AcceptMove(player *Player) error
+	// Challenge challenges the pending move on behalf of the challenger. A phony is taken back to the rack of the
+	// player who played it and scores nothing. If all words are valid, the move is scored and the challenger is
+	// penalized according to the challenge rule.
+	Challenge(challenger *Player) (ChallengeResult, error)
+}
+
+func (game *Game) AcceptMove(player *Player) error {
+	if err := game.checkPending(player); err != nil {
+		return err
+	}
+	zap.L().Debug(fmt.Sprintf("Player '%s' accepted the move of player '%s'", player.Name, game.Pending.Player.Name))
+	game.commitPendingMove(nil)
+	return nil
+}
+
+func (game *Game) Challenge(challenger *Player) (ChallengeResult, error) {
+	if err := game.checkPending(challenger); err != nil {
+		return ChallengeResult{}, err
+	}
+	pending := game.Pending
+	result := ChallengeResult{
+		Phony:        len(pending.result.InvalidWords) > 0,
+		InvalidWords: pending.result.InvalidWords,
+	}
+	if result.Phony {
+		game.withdrawPendingMove(challenger)
+	} else {
+		switch game.Rules.Challenge {
+		case SingleChallenge:
+			result.Penalty = game.Rules.ChallengePenalty
+			challenger.Score -= result.Penalty
+			game.publish(ScoreChanged{Player: challenger, Score: challenger.Score})
+		case DoubleChallenge:
+			result.LostTurn = true
+			if game.lostTurns == nil {
+				game.lostTurns = map[*Player]bool{}
+			}
+			game.lostTurns[challenger] = true
+		}
+	}
+	zap.L().Debug(fmt.Sprintf("Player '%s' challenged the move of player '%s' (phony: %t)",
+		challenger.Name, pending.Player.Name, result.Phony))
+	game.publish(MoveChallenged{Challenger: challenger, Player: pending.Player, Result: result})
+	if !result.Phony {
+		game.commitPendingMove(challenger)
+	}
+	return result, nil
+}
+
+// isChallengeable checks if moves have to be accepted or challenged, which needs another player to do so
+func (game *Game) isChallengeable() bool {
+	return game.Rules.Challenge != VoidChallenge && len(game.Players) > 1
+}
+
+// checkPending checks if the player may accept or challenge the pending move
+func (game *Game) checkPending(player *Player) error {
+	if game.Phase != PhaseInProgress {
+		return ErrGameNotInProgress
+	}
+	if game.Pending == nil {
+		return ErrNoMovePending
+	}
+	if game.Pending.Player == player {
+		return ErrOwnMove
+	}
+	return nil
+}
+
+// playPendingMove places the temporary moves of the player on the board to be accepted or challenged. Only the words
+// are not checked, so the result neither tells the player nor the opponents whether the move is a phony.
+func (game *Game) playPendingMove(player *Player, result MoveCheckResult) (MoveScore, MoveCheckResult) {
+	checked := MoveCheckResult{
+		Violations:   make([]RuleViolation, 0),
+		Words:        result.Words,
+		InvalidWords: make([]Word, 0),
+	}
+	for _, violation := range result.Violations {
+		if violation != UnknownWords {
+			checked.Violations = append(checked.Violations, violation)
+		}
+	}
+	if len(checked.Violations) > 0 {
+		zap.L().Debug("Cannot play temporary moves. Move is invalid", zap.Stringer("reasons", checked))
+		return MoveScore{}, checked
+	}
+	checked.IsValid = true
+	before := game.captureState(player)
+	score := game.Board.ScoreMove(game.TemporaryMoves[player])
+	placed := game.placeMove(player, game.TemporaryMoves[player])
+	game.Pending = &PendingMove{
+		Player: player,
+		Move:   placed,
+		Score:  score,
+		Words:  result.Words,
+		result: result,
+		before: before,
+	}
+	zap.L().Debug(fmt.Sprintf("Player '%s' played a move to be accepted or challenged", player.Name))
+	game.publish(MovePending{Player: player, Move: placed, Score: score})
+	return score, checked
+}
+
+// commitPendingMove scores the pending move and ends the turn of its player
+func (game *Game) commitPendingMove(challenger *Player) {
+	pending := game.Pending
+	game.Pending = nil
+	game.creditMove(pending.Player, pending.Move, pending.Score)
+	drawn := game.PullNewTilesFromBag(pending.Player)
+	game.endTurn(pending.Score.Total)
+	game.recordTurn(Turn{
+		Kind:       PlayTurn,
+		Player:     pending.Player,
+		Move:       pending.Move,
+		Score:      pending.Score,
+		Drawn:      drawn,
+		Challenger: challenger,
+	}, pending.before)
+}
+
+// withdrawPendingMove takes the tiles of the pending move back to the rack of its player and ends the turn without
+// points
+func (game *Game) withdrawPendingMove(challenger *Player) {
+	pending := game.Pending
+	game.Pending = nil
+	for _, m := range pending.Move {
+		game.Board.RemoveTileByCoordinates(m.X, m.Y)
+		tile := *m.Tile
+		tile.ResetAssignment()
+		pending.Player.Tiles = append(pending.Player.Tiles, tile)
+	}
+	game.endTurn(0)
+	game.recordTurn(Turn{
+		Kind:       WithdrawnTurn,
+		Player:     pending.Player,
+		Withdrawn:  pending.Move,
+		Challenger: challenger,
+	}, pending.before)
+}
+
+// forfeitTurn passes the turn of the current player who lost a double challenge and returns the player
+func (game *Game) forfeitTurn() *Player {
+	player := game.CurrentPlayer
+	delete(game.lostTurns, player)
+	zap.L().Debug(fmt.Sprintf("Player '%s' forfeits the turn", player.Name))
+	game.publish(Passed{Player: player})
+	game.endTurn(0)
+	return player
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// newChallengeGame creates a started game of two players with the given challenge rule
+func newChallengeGame(t *testing.T, rule ChallengeRule, words ...string) (*Game, *Player, *Player) {
+	myGame := newTestLobby("Player 1", "Player 2")
+	myGame.Lexicon = newTestDictionary(words...)
+	myGame.Rules.Challenge = rule
+	myGame.Rules.ChallengePenalty = 5
+	assert.NoError(t, myGame.Start())
+	player := myGame.CurrentPlayer
+	return myGame, player, myGame.nextPlayer()
+}
+
+// placeWordOnRack replaces the rack of the current player with the tiles of the word and places them as temporary moves
+func placeWordOnRack(myGame *Game, word string, x int, y int, direction Direction) {
+	player := myGame.CurrentPlayer
+	player.Tiles = nil
+	for _, m := range newMove(myGame.Board, word, x, y, direction) {
+		player.Tiles = append(player.Tiles, *m.Tile)
+		myGame.AddTemporaryMove(player, m)
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	t.Run("Void challenge rejects unknown words", func(t *testing.T) {
+		myGame, player, _ := newChallengeGame(t, VoidChallenge, "cat")
+		placeWordOnRack(myGame, "CTA", 6, 7, Horizontal)
+		_, result := myGame.PlayTemporaryMoves(player)
+		assert.Equal(t, []RuleViolation{UnknownWords}, result.Violations)
+		assert.Nil(t, myGame.Pending)
+	})
+
+	t.Run("Phony is pending without revealing it", func(t *testing.T) {
+		myGame, player, opponent := newChallengeGame(t, SingleChallenge, "cat")
+		placeWordOnRack(myGame, "CTA", 6, 7, Horizontal)
+		score, result := myGame.PlayTemporaryMoves(player)
+		assert.True(t, result.IsValid)
+		assert.Empty(t, result.InvalidWords)
+		assert.Equal(t, 10, score.Total)
+		assert.NotNil(t, myGame.Pending)
+		assert.Equal(t, "CTA", myGame.Board.wordAt(6, 7, Horizontal, nil).String())
+		assert.Equal(t, 0, player.Score)
+		assert.Equal(t, ErrMovePending, myGame.Pass(player))
+		assert.Equal(t, ErrNotYourTurn, myGame.Pass(opponent))
+		assert.Equal(t, ErrOwnMove, myGame.AcceptMove(player))
+	})
+
+	t.Run("Successful challenge withdraws the phony", func(t *testing.T) {
+		myGame, player, opponent := newChallengeGame(t, SingleChallenge, "cat")
+		placeWordOnRack(myGame, "CTA", 6, 7, Horizontal)
+		myGame.PlayTemporaryMoves(player)
+		result, err := myGame.Challenge(opponent)
+		assert.NoError(t, err)
+		assert.True(t, result.Phony)
+		assert.Equal(t, []string{"CTA"}, wordStrings(result.InvalidWords))
+		assert.True(t, myGame.Board.IsEmpty())
+		assert.Len(t, player.Tiles, 3)
+		assert.Equal(t, 0, player.Score)
+		assert.Equal(t, 0, opponent.Score)
+		assert.True(t, myGame.IsTurn(opponent))
+		assert.Equal(t, WithdrawnTurn, myGame.History[0].Kind)
+		assert.Equal(t, opponent, myGame.History[0].Challenger)
+		assert.Equal(t, 1, myGame.ScorelessTurns)
+	})
+
+	t.Run("Failed single challenge costs the penalty", func(t *testing.T) {
+		myGame, player, opponent := newChallengeGame(t, SingleChallenge, "cat")
+		placeWordOnRack(myGame, "CAT", 6, 7, Horizontal)
+		myGame.PlayTemporaryMoves(player)
+		result, err := myGame.Challenge(opponent)
+		assert.NoError(t, err)
+		assert.False(t, result.Phony)
+		assert.Equal(t, 5, result.Penalty)
+		assert.Equal(t, 10, player.Score)
+		assert.Equal(t, -5, opponent.Score)
+		assert.True(t, myGame.IsTurn(opponent))
+		assert.Len(t, player.Tiles, RackSize)
+	})
+
+	t.Run("Failed double challenge costs the next turn", func(t *testing.T) {
+		myGame, player, opponent := newChallengeGame(t, DoubleChallenge, "cat")
+		placeWordOnRack(myGame, "CAT", 6, 7, Horizontal)
+		myGame.PlayTemporaryMoves(player)
+		result, err := myGame.Challenge(opponent)
+		assert.NoError(t, err)
+		assert.True(t, result.LostTurn)
+		assert.Equal(t, 0, opponent.Score)
+		assert.True(t, myGame.IsTurn(player))
+		assert.Len(t, myGame.History, 1)
+		assert.Equal(t, []*Player{opponent}, myGame.History[0].Forfeited)
+	})
+
+	t.Run("Undo takes back the challenged move together with the lost turn", func(t *testing.T) {
+		myGame, player, opponent := newChallengeGame(t, DoubleChallenge, "cat")
+		placeWordOnRack(myGame, "CAT", 6, 7, Horizontal)
+		myGame.PlayTemporaryMoves(player)
+		_, err := myGame.Challenge(opponent)
+		assert.NoError(t, err)
+
+		assert.NoError(t, myGame.Undo())
+		assert.False(t, myGame.CanUndo())
+		assert.True(t, myGame.IsTurn(player))
+		assert.True(t, myGame.Board.IsEmpty())
+		assert.NoError(t, myGame.Redo())
+		assert.True(t, myGame.IsTurn(player))
+		assert.Equal(t, 1, myGame.ScorelessTurns)
+		assert.NoError(t, myGame.Pass(player))
+		assert.True(t, myGame.IsTurn(opponent))
+	})
+
+	t.Run("Accepted move is scored", func(t *testing.T) {
+		myGame, player, opponent := newChallengeGame(t, DoubleChallenge)
+		placeWordOnRack(myGame, "CTA", 6, 7, Horizontal)
+		myGame.PlayTemporaryMoves(player)
+		assert.NoError(t, myGame.Execute(AcceptCommand{Player: opponent}))
+		assert.Nil(t, myGame.Pending)
+		assert.Equal(t, 10, player.Score)
+		assert.True(t, myGame.IsTurn(opponent))
+		assert.Equal(t, ErrNoMovePending, myGame.AcceptMove(opponent))
+	})
+
+	t.Run("Single player game cannot be challenged", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		myGame.Rules.Challenge = DoubleChallenge
+		placeWordOnRack(myGame, "CTA", 6, 7, Horizontal)
+		_, result := myGame.PlayTemporaryMoves(myGame.CurrentPlayer)
+		assert.False(t, result.IsValid)
+		assert.Nil(t, myGame.Pending)
+	})
+}
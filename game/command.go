@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This represents the serialized access to a game shared by several goroutines, e.g. the GUI, network peers and bots.
+// All changes go through Execute and reads through View.
+
+// Command is an action which changes the game. Commands are applied one after another by Game.Execute.
+type Command interface {
+	Apply(game *Game) error
+}
+
+// CommandExecuted is published after a command was applied successfully
+type CommandExecuted struct {
+	Command Command
+}
+
+func (event CommandExecuted) String() string {
+	return fmt.Sprintf("executed %T", event.Command)
+}
+
+var ErrInvalidMove = errors.New("invalid move")
+
+type CommandActions interface {
+	// Execute applies the command while no other command is applied and no other goroutine views the game. The events
+	// caused by the command are published to the subscribers.
+	Execute(command Command) error
+	// View calls the function with the game while no command is applied. The function must not change the game.
+	View(view func(game *Game))
+}
+
+func (game *Game) Execute(command Command) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	if err := command.Apply(game); err != nil {
+		return err
+	}
+	game.publish(CommandExecuted{Command: command})
+	return nil
+}
+
+func (game *Game) View(view func(game *Game)) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	view(game)
+}
+
+// PlaceTileCommand adds a tile placed by the player to the temporary moves
+type PlaceTileCommand struct {
+	Player *Player
+	Move   Move
+}
+
+func (command PlaceTileCommand) Apply(game *Game) error {
+	if err := game.CheckTurn(command.Player); err != nil {
+		return err
+	}
+	game.AddTemporaryMove(command.Player, command.Move)
+	return nil
+}
+
+// TakeBackTileCommand removes a tile from the temporary moves of the player
+type TakeBackTileCommand struct {
+	Player *Player
+	Move   Move
+}
+
+func (command TakeBackTileCommand) Apply(game *Game) error {
+	game.RemoveTemporaryMove(command.Player, command.Move)
+	return nil
+}
+
+// ResetTilesCommand takes back all temporary moves of the player
+type ResetTilesCommand struct {
+	Player *Player
+}
+
+func (command ResetTilesCommand) Apply(game *Game) error {
+	game.ResetTemporaryMoves(command.Player)
+	return nil
+}
+
+// PlayCommand plays the temporary moves of the player. Score and Result are set once the command is applied. An
+// invalid move is rejected with ErrInvalidMove.
+type PlayCommand struct {
+	Player *Player
+	Score  MoveScore
+	Result MoveCheckResult
+}
+
+func (command *PlayCommand) Apply(game *Game) error {
+	if err := game.CheckTurn(command.Player); err != nil {
+		return err
+	}
+	command.Score, command.Result = game.PlayTemporaryMoves(command.Player)
+	if !command.Result.IsValid {
+		return fmt.Errorf("%w: %s", ErrInvalidMove, command.Result)
+	}
+	return nil
+}
+
+// AcceptCommand accepts the pending move on behalf of the player
+type AcceptCommand struct {
+	Player *Player
+}
+
+func (command AcceptCommand) Apply(game *Game) error {
+	return game.AcceptMove(command.Player)
+}
+
+// ChallengeCommand challenges the pending move on behalf of the challenger. Result is set once the command is applied.
+type ChallengeCommand struct {
+	Challenger *Player
+	Result     ChallengeResult
+}
+
+func (command *ChallengeCommand) Apply(game *Game) (err error) {
+	command.Result, err = game.Challenge(command.Challenger)
+	return err
+}
+
+// PassCommand passes the turn of the player
+type PassCommand struct {
+	Player *Player
+}
+
+func (command PassCommand) Apply(game *Game) error {
+	return game.Pass(command.Player)
+}
+
+// ExchangeCommand exchanges the given tiles of the player's rack
+type ExchangeCommand struct {
+	Player *Player
+	Tiles  []Tile
+}
+
+func (command ExchangeCommand) Apply(game *Game) error {
+	return game.ExchangeTiles(command.Player, command.Tiles)
+}
+
+// AddPlayerCommand adds a player to a game which is not started yet
+type AddPlayerCommand struct {
+	Player *Player
+}
+
+func (command AddPlayerCommand) Apply(game *Game) error {
+	return game.AddPlayer(command.Player)
+}
+
+// StartCommand starts the game
+type StartCommand struct{}
+
+func (command StartCommand) Apply(game *Game) error {
+	return game.Start()
+}
+
+// UndoCommand takes back the last committed turn
+type UndoCommand struct{}
+
+func (command UndoCommand) Apply(game *Game) error {
+	return game.Undo()
+}
+
+// RedoCommand commits the last turn taken back again
+type RedoCommand struct{}
+
+func (command RedoCommand) Apply(game *Game) error {
+	return game.Redo()
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecute(t *testing.T) {
+	t.Run("Commands from several goroutines are serialized", func(t *testing.T) {
+		myGame := newTestLobby("Player 1", "Player 2")
+		assert.NoError(t, myGame.Execute(StartCommand{}))
+		var wait sync.WaitGroup
+		for _, player := range myGame.Players {
+			wait.Add(1)
+			go func(player *Player) {
+				defer wait.Done()
+				for finished := false; !finished; {
+					var tile Tile
+					myGame.View(func(game *Game) {
+						tile = player.Tiles[0]
+						finished = game.Phase == PhaseFinished
+						_ = game.Standings().String()
+					})
+					_ = myGame.Execute(PlaceTileCommand{Player: player, Move: Move{X: 7, Y: 7, Tile: &tile}})
+					_ = myGame.Execute(ResetTilesCommand{Player: player})
+					_ = myGame.Execute(PassCommand{Player: player})
+				}
+			}(player)
+		}
+		wait.Wait()
+		myGame.View(func(game *Game) {
+			assert.Equal(t, PhaseFinished, game.Phase)
+			assert.Equal(t, MaxScorelessTurns, game.ScorelessTurns)
+		})
+	})
+
+	t.Run("Rejected command is not published", func(t *testing.T) {
+		myGame := newTestLobby("Player 1", "Player 2")
+		events := make(chan Event, 10)
+		unsubscribe := myGame.Subscribe(func(event Event) {
+			if _, ok := event.(CommandExecuted); ok {
+				events <- event
+			}
+		})
+		defer unsubscribe()
+		assert.ErrorIs(t, myGame.Execute(PassCommand{Player: myGame.Players[0]}), ErrGameNotInProgress)
+		assert.NoError(t, myGame.Execute(StartCommand{}))
+		assert.Equal(t, "executed game.StartCommand", receive(t, events).String())
+	})
+
+	t.Run("Invalid move is rejected", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		player := myGame.CurrentPlayer
+		for _, m := range newMove(myGame.Board, "CTA", 6, 7, Horizontal) {
+			player.Tiles = append(player.Tiles, *m.Tile)
+			assert.NoError(t, myGame.Execute(PlaceTileCommand{Player: player, Move: m}))
+		}
+		command := &PlayCommand{Player: player}
+		assert.ErrorIs(t, myGame.Execute(command), ErrInvalidMove)
+		assert.Equal(t, []RuleViolation{UnknownWords}, command.Result.Violations)
+	})
+
+	t.Run("Subscribers receive events in order", func(t *testing.T) {
+		myGame := newTestLobby("Player 1", "Player 2")
+		events := make(chan Event, 10)
+		unsubscribe := myGame.Subscribe(func(event Event) {
+			if _, ok := event.(CommandExecuted); ok {
+				events <- event
+			}
+		})
+		assert.NoError(t, myGame.Execute(StartCommand{}))
+		assert.NoError(t, myGame.Execute(PassCommand{Player: myGame.CurrentPlayer}))
+		assert.NoError(t, myGame.Execute(UndoCommand{}))
+		assert.Equal(t, "executed game.StartCommand", receive(t, events).String())
+		assert.Equal(t, "executed game.PassCommand", receive(t, events).String())
+		assert.Equal(t, "executed game.UndoCommand", receive(t, events).String())
+		unsubscribe()
+		assert.NoError(t, myGame.Execute(RedoCommand{}))
+		select {
+		case event := <-events:
+			assert.Fail(t, "unexpected event after unsubscribing", event.String())
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+}
+
+// receive waits for the next event of the subscription
+func receive(t *testing.T, events chan Event) Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return nil
+	}
+}
@@ -4,10 +4,16 @@ package game
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/smhanov/dawg"
 	"go.uber.org/zap"
-	"log"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"slices"
@@ -25,75 +31,202 @@ type Dictionary struct {
 	WordFinder dawg.Finder
 	// Store length by word
 	WordStats map[string]WordStats
+	// TileSet is used to count and score the letters of the words
+	TileSet TileSet
+	// Name of the dictionary, e.g. the file name without extension
+	Name string
+	// Checksum of the dawg data, which identifies the words of the dictionary
+	Checksum string
 }
 
 type DictionaryActions interface {
 	IsWord(word string) bool
+	// ID identifies the dictionary by its name and checksum, see Lexicon
+	ID() string
 	FindWords(prefix string) []string
 	GetWordStats(word string) *WordStats
-	ExportStatsCSV(path string)
+	ExportStatsCSV(path string) error
 	FindNLongestWords(n int) []string
 	FindNHighestScoringWords(n int) []string
 	FindNHighestRelativeScoringWords(n int) []string
 	initWordsStats()
 }
 
+var (
+	ErrInvalidDAWG = errors.New("invalid dawg data")
+	ErrNoWords     = errors.New("dictionary has no words")
+)
+
 func filenameWithoutExtension(fileName string) string {
 	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
 }
 
-// LoadDictionary loads a dictionary. It first checks if a dawg file exists in the same directory as the csv file.
-// If it does, it loads the dawg file, otherwise it creates it and saves it.
-func LoadDictionary(path string) *Dictionary {
-	// Check if the dawg file exists, if not, create it
+// checksumPath returns the path of the file holding the checksum of the csv file a dawg file was created from
+func checksumPath(dawgPath string) string {
+	return dawgPath + ".sha256"
+}
+
+// LoadDictionary loads a dictionary from a csv file and caches it as a dawg file in the same directory. The cached dawg
+// file is used unless it is stale, i.e. the csv file is newer or its checksum differs from the one the dawg file was
+// created from. Without a csv file, an existing dawg file is loaded. Failing to write the cache is not an error.
+func LoadDictionary(path string, tiles TileSet) (*Dictionary, error) {
 	dawgPath := filenameWithoutExtension(path) + ".dawg"
-	zap.S().Debugf("Checking if dawg file exists: %s", dawgPath)
-	if _, err := os.Stat(dawgPath); os.IsExist(err) {
-		return NewDictionaryFromDAWG(dawgPath)
-	} else {
-		return NewDictionaryFromCSV(path)
+	stale, checksum, err := isDAWGStale(path, dawgPath)
+	if err != nil {
+		return nil, err
+	}
+	if !stale {
+		zap.S().Debugf("Using cached dawg file: %s", dawgPath)
+		return NewDictionaryFromDAWG(dawgPath, tiles)
+	}
+	dictionary, err := NewDictionaryFromCSV(path, tiles)
+	if err != nil {
+		return nil, err
 	}
+	zap.S().Debugf("Saving dawg file: %s", dawgPath)
+	if _, err := dictionary.WordFinder.Save(dawgPath); err != nil {
+		zap.S().Warnf("Failed to cache dictionary '%s': %s", path, err)
+	} else if err := os.WriteFile(checksumPath(dawgPath), []byte(checksum+"\n"), 0644); err != nil {
+		zap.S().Warnf("Failed to write checksum of dictionary '%s': %s", path, err)
+	}
+	return dictionary, nil
 }
 
-func NewDictionaryFromDAWG(path string) *Dictionary {
-	dictionary := &Dictionary{}
+// isDAWGStale checks if the dawg file has to be created from the csv file again. The checksum of the csv file is
+// returned along with the result, empty if there is no csv file.
+func isDAWGStale(csvPath string, dawgPath string) (bool, string, error) {
+	dawgInfo, dawgErr := os.Stat(dawgPath)
+	csvInfo, err := os.Stat(csvPath)
+	if errors.Is(err, fs.ErrNotExist) && dawgErr == nil {
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to load dictionary: %w", err)
+	}
+	checksum, err := fileChecksum(csvPath)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to load dictionary: %w", err)
+	}
+	if dawgErr != nil || csvInfo.ModTime().After(dawgInfo.ModTime()) {
+		return true, checksum, nil
+	}
+	// Dawg files without a checksum, e.g. the ones shipped with the game, are only checked by their modification time
+	stored, err := os.ReadFile(checksumPath(dawgPath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, checksum, nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to load dictionary: %w", err)
+	}
+	return strings.TrimSpace(string(stored)) != checksum, checksum, nil
+}
 
-	finder, err := dawg.Load(path)
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		zap.S().Errorf("Error loading dawg file: %s", err)
-		fmt.Println(err)
+		return "", err
 	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
 
-	dictionary.WordFinder = finder
+// LoadDictionaryFromFS loads a dictionary from a dawg or csv file of the file system, e.g. an embed.FS. The format is
+// told by the file extension.
+func LoadDictionaryFromFS(fsys fs.FS, path string, tiles TileSet) (*Dictionary, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dictionary: %w", err)
+	}
+	defer file.Close()
+	var dictionary *Dictionary
+	if filepath.Ext(path) == ".csv" {
+		dictionary, err = ReadDictionaryFromCSV(file, tiles)
+	} else {
+		dictionary, err = ReadDictionaryFromDAWG(file, tiles)
+	}
+	if err != nil {
+		return nil, err
+	}
+	dictionary.Name = filenameWithoutExtension(filepath.Base(path))
+	return dictionary, nil
+}
 
-	dictionary.initWordsStats()
+// NewDictionaryFromDAWG loads a dictionary from a dawg file, whose words are played with the given tiles
+func NewDictionaryFromDAWG(path string, tiles TileSet) (*Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dictionary: %w", err)
+	}
+	defer file.Close()
+	dictionary, err := ReadDictionaryFromDAWG(file, tiles)
+	if err != nil {
+		return nil, err
+	}
+	dictionary.Name = filenameWithoutExtension(filepath.Base(path))
+	return dictionary, nil
+}
 
-	return dictionary
+// ReadDictionaryFromDAWG reads a dictionary in the dawg format, whose words are played with the given tiles. The
+// dictionary has no name.
+func ReadDictionaryFromDAWG(reader io.Reader, tiles TileSet) (*Dictionary, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dawg: %w", err)
+	}
+	// The dawg starts with its size, checking it keeps the dawg package from panicking on truncated data
+	if len(data) < 4 || binary.BigEndian.Uint32(data) != uint32(len(data)) {
+		return nil, ErrInvalidDAWG
+	}
+	finder, err := dawg.Read(bytes.NewReader(data), 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDAWG, err)
+	}
+	checksum := sha256.Sum256(data)
+	dictionary := &Dictionary{WordFinder: finder, TileSet: tiles, Checksum: hex.EncodeToString(checksum[:])}
+	dictionary.initWordsStats()
+	return dictionary, nil
 }
 
-// NewDictionaryFromCSV loads a dictionary from a csv file, creates a DAWG from it and returns a Dictionary
-// If the according DAWG file in the same directory does not exist, it will be created. All entries are stored as
-// lowercase words.
-func NewDictionaryFromCSV(path string) *Dictionary {
-	dictionary := &Dictionary{}
+// NewDictionaryFromCSV loads a dictionary from a csv file with one word per line, whose words are played with the
+// given tiles. All entries are stored as lowercase words.
+func NewDictionaryFromCSV(path string, tiles TileSet) (*Dictionary, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		zap.S().Errorf("Error opening file: %s", err)
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to load dictionary: %w", err)
 	}
 	defer file.Close()
+	dictionary, err := ReadDictionaryFromCSV(file, tiles)
+	if err != nil {
+		return nil, err
+	}
+	dictionary.Name = filenameWithoutExtension(filepath.Base(path))
+	return dictionary, nil
+}
 
+// ReadDictionaryFromCSV reads a dictionary with one word per line, whose words are played with the given tiles. The
+// dictionary has no name.
+func ReadDictionaryFromCSV(reader io.Reader, tiles TileSet) (*Dictionary, error) {
 	words := make(map[string]bool)
-
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(reader)
 	// might fail for lines longer than 64K!
 	for scanner.Scan() {
-		word := strings.ToLower(scanner.Text())
-		words[word] = true
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word != "" {
+			words[word] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	if len(words) == 0 {
+		return nil, ErrNoWords
 	}
 
 	// sort words alphabetically
-
 	keys := make([]string, 0, len(words))
 	for k := range words {
 		keys = append(keys, k)
@@ -105,24 +238,15 @@ func NewDictionaryFromCSV(path string) *Dictionary {
 	for _, word := range keys {
 		dawgBuilder.Add(word)
 	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	dictionary := &Dictionary{WordFinder: dawgBuilder.Finish(), TileSet: tiles}
+	// The checksum is the one of the dawg data, so loading the cached dawg file results in the same ID
+	hash := sha256.New()
+	if _, err := dictionary.WordFinder.Write(hash); err != nil {
+		return nil, fmt.Errorf("failed to create dawg: %w", err)
 	}
-
-	dictionary.WordFinder = dawgBuilder.Finish()
-
-	// Save the dawg to a file
-	dawgPath := filenameWithoutExtension(path) + ".dawg"
-	zap.S().Debugf("Saving dawg file: %s", dawgPath)
-	_, err = dictionary.WordFinder.Save(dawgPath)
-	if err != nil {
-		zap.S().Errorf("Error saving dawg file: %s", err)
-	}
-
+	dictionary.Checksum = hex.EncodeToString(hash.Sum(nil))
 	dictionary.initWordsStats()
-
-	return dictionary
+	return dictionary, nil
 }
 
 func (dictionary *Dictionary) FindNLongestWords(n int) []string {
@@ -173,29 +297,31 @@ func (dictionary *Dictionary) FindNHighestRelativeScoringWords(n int) []string {
 	return words[:n]
 }
 
-func (dictionary *Dictionary) ExportStatsCSV(path string) {
+// ExportStatsCSV writes the stats of all words to a csv file
+func (dictionary *Dictionary) ExportStatsCSV(path string) error {
 	file, err := os.Create(path)
 	if err != nil {
-		zap.S().Errorf("Error creating file: %s", err)
-		log.Fatal(err)
+		return fmt.Errorf("failed to export stats: %w", err)
 	}
 	defer file.Close()
 
+	writer := bufio.NewWriter(file)
 	// Write header
-	_, err = file.WriteString("word,word_length,word_score,word_relative_score\n")
-	if err != nil {
-		zap.S().Errorf("Error writing to file: %s", err)
-		log.Fatal(err)
+	if _, err := writer.WriteString("word,word_length,word_score,word_relative_score\n"); err != nil {
+		return fmt.Errorf("failed to export stats: %w", err)
 	}
 
 	// Write stats
 	for word, stats := range dictionary.WordStats {
-		_, err = file.WriteString(fmt.Sprintf("%s,%d,%d,%f\n", word, stats.WordLength, stats.WordScore, stats.WordRelativeScore))
+		_, err := fmt.Fprintf(writer, "%s,%d,%d,%f\n", word, stats.WordLength, stats.WordScore, stats.WordRelativeScore)
 		if err != nil {
-			zap.S().Errorf("Error writing to file: %s", err)
-			log.Fatal(err)
+			return fmt.Errorf("failed to export stats: %w", err)
 		}
 	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to export stats: %w", err)
+	}
+	return nil
 }
 
 func (dictionary *Dictionary) GetWordStats(word string) *WordStats {
@@ -206,6 +332,10 @@ func (dictionary *Dictionary) GetWordStats(word string) *WordStats {
 	return &stats
 }
 
+func (dictionary *Dictionary) ID() string {
+	return lexiconID(dictionary.Name, dictionary.Checksum)
+}
+
 func (dictionary *Dictionary) IsWord(word string) bool {
 	return dictionary.WordFinder.IndexOf(strings.ToLower(word)) != -1
 }
@@ -214,7 +344,6 @@ func (dictionary *Dictionary) FindWords(prefix string) []string {
 	findResults := dictionary.WordFinder.FindAllPrefixesOf(strings.ToLower(prefix))
 	words := make([]string, len(findResults))
 	for i, result := range findResults {
-		fmt.Printf("%d: %s\n", i, result.Word)
 		words[i] = result.Word
 	}
 	return words
@@ -225,8 +354,9 @@ func (dictionary *Dictionary) initWordsStats() {
 	dictionary.WordFinder.Enumerate(
 		func(index int, word []rune, final bool) int {
 			if final {
-				wordLength := len(word)
-				wordScore := CalculateWordScore(string(word))
+				letters, _ := dictionary.TileSet.SplitLetters(string(word))
+				wordLength := len(letters)
+				wordScore := CalculateWordScore(string(word), &dictionary.TileSet)
 				dictionary.WordStats[string(word)] = WordStats{
 					WordLength:        wordLength,
 					WordScore:         wordScore,
@@ -1,17 +1,24 @@
 package game
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func TestDictionary(t *testing.T) {
 	// Test the ParseFlags function
 	t.Run("Test Load Dictionary", func(t *testing.T) {
-		dict := LoadDictionary("../assets/dicts/en.csv")
-		if dict == nil {
-			t.Errorf("Dictionary not loaded")
+		dict, err := LoadDictionary("../assets/dicts/en.csv", EnglishTileSet())
+		if err != nil {
+			t.Errorf("Dictionary not loaded: %s", err)
 		}
 		assert.Equal(t, dict.IsWord("hello"), true)
 		prefixes := dict.WordFinder.FindAllPrefixesOf("abortivenesses")
@@ -21,9 +28,9 @@ func TestDictionary(t *testing.T) {
 		}
 	})
 	t.Run("Test Load Dictionary from DAWG", func(t *testing.T) {
-		dict := NewDictionaryFromDAWG("../assets/dicts/en.dawg")
-		if dict == nil {
-			t.Errorf("Dictionary not loaded")
+		dict, err := NewDictionaryFromDAWG("../assets/dicts/en.dawg", EnglishTileSet())
+		if err != nil {
+			t.Errorf("Dictionary not loaded: %s", err)
 		}
 		assert.Equal(t, dict.IsWord("hello"), true)
 		prefixes := dict.WordFinder.FindAllPrefixesOf("abortivenesses")
@@ -34,18 +41,20 @@ func TestDictionary(t *testing.T) {
 	})
 	// Load all dicts
 	t.Run("Test Load All Dictionaries", func(t *testing.T) {
-		languages := []string{"en", "de", "fr", "es"}
+		languages := []string{"en", "de2", "fr", "es"}
+		dir := t.TempDir()
 		for _, language := range languages {
-			dict := LoadDictionary("../assets/dicts/" + language + ".csv")
-			if dict == nil {
+			dict, err := LoadDictionary("../assets/dicts/"+language+".csv", EnglishTileSet())
+			if err != nil {
 				t.Errorf("Failed to load dictionary for language: %s", language)
+				continue
 			}
-			dict.ExportStatsCSV("../assets/dicts/" + language + ".stats.csv")
+			assert.NoError(t, dict.ExportStatsCSV(filepath.Join(dir, language+".stats.csv")))
 		}
 	})
 
 	t.Run("Get Some Word Stats", func(t *testing.T) {
-		dict := NewDictionaryFromDAWG("../assets/dicts/en.dawg")
+		dict, _ := NewDictionaryFromDAWG("../assets/dicts/en.dawg", EnglishTileSet())
 		wordStats := dict.GetWordStats("axolotl")
 		fmt.Println(wordStats)
 	})
@@ -54,28 +63,135 @@ func TestDictionary(t *testing.T) {
 		// languages := []string{"en", "de", "fr", "es"}
 		languages := []string{"de2"}
 		for _, language := range languages {
-			dict := LoadDictionary("../assets/dicts/" + language + ".csv")
-			if dict == nil {
+			dict, err := LoadDictionary("../assets/dicts/"+language+".csv", GermanTileSet())
+			if err != nil {
 				t.Errorf("Failed to load dictionary for language: %s", language)
+				continue
 			}
 			n := 20
 			words := dict.FindNHighestScoringWords(n)
 			fmt.Printf("Top %d scoring words for language %s:\n", n, language)
 			for _, word := range words {
-				fmt.Println(fmt.Sprintf("\t%s: %d", word, CalculateWordScore(word)))
+				fmt.Println(fmt.Sprintf("\t%s: %d", word, CalculateWordScore(word, &dict.TileSet)))
 			}
 
 			words = dict.FindNLongestWords(n)
 			fmt.Printf("Top %d longest words for language %s:\n", n, language)
 			for _, word := range words {
-				fmt.Println(fmt.Sprintf("\t%s: %d", word, CalculateWordScore(word)))
+				fmt.Println(fmt.Sprintf("\t%s: %d", word, CalculateWordScore(word, &dict.TileSet)))
 			}
 
 			words = dict.FindNHighestRelativeScoringWords(n)
 			fmt.Printf("Top %d words with highest relative score for language %s:\n", n, language)
 			for _, word := range words {
-				fmt.Println(fmt.Sprintf("\t%s: %d", word, CalculateWordScore(word)))
+				fmt.Println(fmt.Sprintf("\t%s: %d", word, CalculateWordScore(word, &dict.TileSet)))
 			}
 		}
 	})
 }
+
+// writeWords writes the words as a csv file with one word per line
+func writeWords(t *testing.T, path string, words ...string) {
+	assert.NoError(t, os.WriteFile(path, []byte(strings.Join(words, "\n")+"\n"), 0644))
+}
+
+func TestLoadDictionary(t *testing.T) {
+	t.Run("Csv file is cached as dawg file", func(t *testing.T) {
+		dir := t.TempDir()
+		csvPath := filepath.Join(dir, "test.csv")
+		writeWords(t, csvPath, "cat", "dog")
+		dict, err := LoadDictionary(csvPath, EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("CAT"))
+		assert.FileExists(t, filepath.Join(dir, "test.dawg"))
+		assert.FileExists(t, filepath.Join(dir, "test.dawg.sha256"))
+
+		// The cache is used as long as the csv file is unchanged
+		stale, _, err := isDAWGStale(csvPath, filepath.Join(dir, "test.dawg"))
+		assert.NoError(t, err)
+		assert.False(t, stale)
+	})
+
+	t.Run("Changed csv file makes the cache stale", func(t *testing.T) {
+		dir := t.TempDir()
+		csvPath := filepath.Join(dir, "test.csv")
+		dawgPath := filepath.Join(dir, "test.dawg")
+		writeWords(t, csvPath, "cat")
+		_, err := LoadDictionary(csvPath, EnglishTileSet())
+		assert.NoError(t, err)
+
+		// Same modification time, but different content
+		info, _ := os.Stat(dawgPath)
+		writeWords(t, csvPath, "cat", "dog")
+		assert.NoError(t, os.Chtimes(csvPath, info.ModTime(), info.ModTime()))
+		dict, err := LoadDictionary(csvPath, EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("dog"))
+
+		// Newer csv file
+		writeWords(t, csvPath, "cat", "dog", "emu")
+		later := info.ModTime().Add(time.Hour)
+		assert.NoError(t, os.Chtimes(csvPath, later, later))
+		dict, err = LoadDictionary(csvPath, EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("emu"))
+	})
+
+	t.Run("Dawg file without csv file is loaded", func(t *testing.T) {
+		dir := t.TempDir()
+		csvPath := filepath.Join(dir, "test.csv")
+		writeWords(t, csvPath, "cat")
+		_, err := LoadDictionary(csvPath, EnglishTileSet())
+		assert.NoError(t, err)
+		assert.NoError(t, os.Remove(csvPath))
+		dict, err := LoadDictionary(csvPath, EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("cat"))
+	})
+
+	t.Run("Missing files are errors", func(t *testing.T) {
+		_, err := LoadDictionary(filepath.Join(t.TempDir(), "missing.csv"), EnglishTileSet())
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		_, err = NewDictionaryFromDAWG(filepath.Join(t.TempDir(), "missing.dawg"), EnglishTileSet())
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("Dictionaries are read from readers and file systems", func(t *testing.T) {
+		dict, err := ReadDictionaryFromCSV(strings.NewReader("Cat\nDog\n"), EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("dog"))
+		assert.Equal(t, 5, dict.GetWordStats("dog").WordScore)
+
+		var buffer bytes.Buffer
+		_, err = dict.WordFinder.Write(&buffer)
+		assert.NoError(t, err)
+		fsys := fstest.MapFS{
+			"dicts/test.dawg": {Data: buffer.Bytes()},
+			"dicts/test.csv":  {Data: []byte("emu\n")},
+		}
+		dict, err = LoadDictionaryFromFS(fsys, "dicts/test.dawg", EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("cat"))
+		dict, err = LoadDictionaryFromFS(fsys, "dicts/test.csv", EnglishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, dict.IsWord("emu"))
+		_, err = LoadDictionaryFromFS(fsys, "dicts/missing.dawg", EnglishTileSet())
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("Invalid data is an error", func(t *testing.T) {
+		_, err := ReadDictionaryFromDAWG(strings.NewReader("not a dawg"), EnglishTileSet())
+		assert.ErrorIs(t, err, ErrInvalidDAWG)
+		_, err = ReadDictionaryFromCSV(strings.NewReader("\n\n"), EnglishTileSet())
+		assert.ErrorIs(t, err, ErrNoWords)
+	})
+
+	t.Run("Stats are exported", func(t *testing.T) {
+		dict, _ := ReadDictionaryFromCSV(strings.NewReader("cat\n"), EnglishTileSet())
+		path := filepath.Join(t.TempDir(), "stats.csv")
+		assert.NoError(t, dict.ExportStatsCSV(path))
+		content, _ := os.ReadFile(path)
+		assert.Equal(t, "word,word_length,word_score,word_relative_score\ncat,3,5,1.666667\n", string(content))
+		assert.Error(t, dict.ExportStatsCSV(filepath.Join(t.TempDir(), "missing", "stats.csv")))
+	})
+}
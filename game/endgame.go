@@ -0,0 +1,243 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// This represents the end game solver. Once the bag is empty, both racks are known, so the game can be searched to its
+// end. The moves of both players, including passing, are searched with alpha-beta pruning. The search is deepened turn
+// by turn until all lines reach the end of the game or the time is up. Passing after the opponent passed ends the game in
+// the search, as the position is the same as before both passes.
+
+var (
+	ErrBagNotEmpty   = errors.New("the bag is not empty")
+	ErrNotTwoPlayers = errors.New("the end game can only be solved for two players")
+)
+
+// EndgameTurn is a turn of an end game solution
+type EndgameTurn struct {
+	Player   *Player
+	Decision Decision
+	Score    int
+}
+
+// EndgameSolution is the best sequence of turns found by the end game solver
+type EndgameSolution struct {
+	// Turns starts with the turn of the current player, after which the players alternate with their best answers
+	Turns []EndgameTurn
+	// Spread is the number of points the current player scores more than the opponent until the end of the game,
+	// including the end game adjustments. Lines cut off by the depth count as if the game ended there.
+	Spread int
+	// Depth is the number of turns searched
+	Depth int
+	// Complete is set if all lines were searched to the end of the game, so the solution is perfect
+	Complete bool
+}
+
+func (solution EndgameSolution) String() string {
+	lines := make([]string, 0, len(solution.Turns)+1)
+	quality := fmt.Sprintf("%d turns searched", solution.Depth)
+	if solution.Complete {
+		quality = "complete"
+	}
+	lines = append(lines, fmt.Sprintf("Spread %+d (%s)", solution.Spread, quality))
+	for _, turn := range solution.Turns {
+		lines = append(lines, fmt.Sprintf("%s: %s for %d", turn.Player.Name, turn.Decision, turn.Score))
+	}
+	return strings.Join(lines, "\n")
+}
+
+type EndgameActions interface {
+	// Endgame takes a snapshot of the game to solve its end game once the bag is empty. It must be called while viewing
+	// a shared game, see CommandActions, but the snapshot is solved without holding the game.
+	Endgame() (*Endgame, error)
+	// SolveEndgame solves the end game of the game right away, see Endgame. It must be called while viewing a shared
+	// game, which is held until the solver is done.
+	SolveEndgame(generator MoveGenerator, timeLimit time.Duration) (EndgameSolution, error)
+}
+
+// Endgame is a snapshot of a game whose bag is empty, taken with the current player to move
+type Endgame struct {
+	board *Board
+	// players are the current player and the opponent, racks holds their tiles
+	players        [2]*Player
+	racks          [2][]Tile
+	rules          *RuleSet
+	scorelessTurns int
+}
+
+func (game *Game) Endgame() (*Endgame, error) {
+	if err := game.CheckTurn(game.CurrentPlayer); err != nil {
+		return nil, err
+	}
+	if len(game.Bag.Tiles) > 0 {
+		return nil, ErrBagNotEmpty
+	}
+	if len(game.Players) != 2 {
+		return nil, ErrNotTwoPlayers
+	}
+	endgame := &Endgame{
+		board:          game.Board.Copy(),
+		players:        [2]*Player{game.CurrentPlayer, game.nextPlayer()},
+		rules:          game.Rules,
+		scorelessTurns: game.ScorelessTurns,
+	}
+	for i, player := range endgame.players {
+		endgame.racks[i] = append([]Tile{}, player.Tiles...)
+	}
+	return endgame, nil
+}
+
+func (game *Game) SolveEndgame(generator MoveGenerator, timeLimit time.Duration) (EndgameSolution, error) {
+	endgame, err := game.Endgame()
+	if err != nil {
+		return EndgameSolution{}, err
+	}
+	return endgame.Solve(generator, timeLimit), nil
+}
+
+// Solve finds the sequence of turns with the best spread for the player to move. The moves are enumerated by the
+// generator. After the time limit, the solution of the deepest search completed in time is returned.
+func (endgame *Endgame) Solve(generator MoveGenerator, timeLimit time.Duration) EndgameSolution {
+	search := &endgameSearch{
+		generator: generator,
+		board:     endgame.board.Copy(),
+		players:   endgame.players,
+		rules:     endgame.rules,
+		deadline:  time.Now().Add(timeLimit),
+	}
+	for i, rack := range endgame.racks {
+		search.racks[i] = append([]Tile{}, rack...)
+	}
+	var solution EndgameSolution
+	for depth := 1; ; depth++ {
+		search.depth = depth
+		search.horizon = false
+		spread, turns := search.negamax(0, endgame.scorelessTurns, false, depth, -math.MaxInt32, math.MaxInt32)
+		if search.aborted {
+			break
+		}
+		solution = EndgameSolution{Turns: turns, Spread: spread, Depth: depth, Complete: !search.horizon}
+		if solution.Complete || time.Now().After(search.deadline) {
+			break
+		}
+	}
+	return solution
+}
+
+// endgameSearch holds the state of the end game search. The board and the racks are changed while searching a move and
+// restored afterwards.
+type endgameSearch struct {
+	generator MoveGenerator
+	board     *Board
+	// players are the current player and the opponent, racks holds their tiles
+	players [2]*Player
+	racks   [2][]Tile
+	rules   *RuleSet
+	// depth is the depth of the current iteration
+	depth    int
+	deadline time.Time
+	aborted  bool
+	// horizon is set if a line was cut off before the end of the game
+	horizon bool
+	nodes   int
+}
+
+// rackValue returns the sum of the letter scores of the rack of the player
+func (search *endgameSearch) rackValue(player int) int {
+	value := 0
+	for _, tile := range search.racks[player] {
+		value += tile.LetterScore
+	}
+	return value
+}
+
+// endSpread returns the spread of the end game adjustments for the player if the game ends with the current racks. A
+// player who went out is credited the tiles left on the rack of the opponent if the rules say so.
+func (search *endgameSearch) endSpread(player int) int {
+	own, opponent := search.rackValue(player), search.rackValue(1-player)
+	if len(search.racks[player]) == 0 && search.rules.LeftoversToPlayerOut {
+		return 2 * opponent
+	}
+	return opponent - own
+}
+
+// negamax returns the best spread for the player to move along with the turns leading to it. Passed is set if the
+// opponent passed on the last turn.
+func (search *endgameSearch) negamax(player int, scoreless int, passed bool, depth int, alpha int,
+	beta int) (int, []EndgameTurn) {
+	search.nodes++
+	// The first iteration is always completed, so there is a solution
+	if search.depth > 1 && search.nodes%64 == 0 && time.Now().After(search.deadline) {
+		search.aborted = true
+	}
+	if search.aborted {
+		return 0, nil
+	}
+	if depth == 0 {
+		search.horizon = true
+		return search.endSpread(player), nil
+	}
+	best, bestTurns := math.MinInt32, []EndgameTurn(nil)
+	try := func(decision Decision, score int) bool {
+		rack := search.racks[player]
+		search.racks[player] = leftOnRack(rack, decision.Move)
+		for _, move := range decision.Move {
+			search.board.PlaceTile(move.Tile, move.X, move.Y)
+		}
+		nextScoreless := 0
+		if score == 0 {
+			nextScoreless = scoreless + 1
+		}
+		spread, turns := score, []EndgameTurn(nil)
+		pass := len(decision.Move) == 0
+		if len(search.racks[player]) == 0 || nextScoreless >= search.rules.MaxScorelessTurns || (pass && passed) {
+			spread += search.endSpread(player)
+		} else {
+			opponentSpread, opponentTurns := search.negamax(1-player, nextScoreless, pass, depth-1, -beta, -alpha)
+			spread, turns = score-opponentSpread, opponentTurns
+		}
+		for _, move := range decision.Move {
+			search.board.RemoveTileByCoordinates(move.X, move.Y)
+		}
+		search.racks[player] = rack
+		if search.aborted {
+			return false
+		}
+		if spread > best {
+			best = spread
+			turn := EndgameTurn{Player: search.players[player], Decision: decision, Score: score}
+			bestTurns = append([]EndgameTurn{turn}, turns...)
+		}
+		if best > alpha {
+			alpha = best
+		}
+		return alpha < beta
+	}
+	for _, placement := range search.generator.GenerateMoves(search.board, search.racks[player]) {
+		if !try(Decision{Move: placement.Move}, placement.Score.Total) {
+			return best, bestTurns
+		}
+	}
+	try(Decision{}, 0)
+	return best, bestTurns
+}
+
+// leftOnRack returns the tiles of the rack which are not placed by the move
+func leftOnRack(rack []Tile, move []Move) []Tile {
+	left := make([]Tile, 0, len(rack))
+	for _, tile := range rack {
+		placed := false
+		for _, m := range move {
+			placed = placed || m.Tile.ID == tile.ID
+		}
+		if !placed {
+			left = append(left, tile)
+		}
+	}
+	return left
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSolveEndgame(t *testing.T) {
+	words := []string{"car", "cars", "care", "cares", "scare", "ta", "at", "eta"}
+
+	t.Run("Going out scores the tiles of the opponent twice", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S"}, []string{"Q", "Z"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		solution, err := myGame.SolveEndgame(generator, time.Second)
+		assert.NoError(t, err)
+		assert.True(t, solution.Complete)
+		assert.Len(t, solution.Turns, 1)
+		assert.Equal(t, myGame.Players[0], solution.Turns[0].Player)
+		assert.Equal(t, 6, solution.Turns[0].Score)
+		assert.Equal(t, 6+2*20, solution.Spread)
+	})
+
+	t.Run("The player passes if the opponent can go out", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"Q"}, []string{"S"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		solution, err := myGame.SolveEndgame(generator, time.Second)
+		assert.NoError(t, err)
+		assert.True(t, solution.Complete)
+		assert.Len(t, solution.Turns, 2)
+		assert.Equal(t, Decision{}, solution.Turns[0].Decision)
+		assert.Equal(t, myGame.Players[1], solution.Turns[1].Player)
+		assert.Equal(t, -(6 + 2*10), solution.Spread)
+	})
+
+	t.Run("Setting up going out beats the highest scoring move", func(t *testing.T) {
+		// Playing "SCARE" scores most but leaves the T, playing "TA" first leaves "SE" for "SCARE" to go out
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S", "E", "T"}, []string{"Q", "Z", "X"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		solution, err := myGame.SolveEndgame(generator, 10*time.Second)
+		assert.NoError(t, err)
+		assert.True(t, solution.Complete)
+		greedy := generator.GenerateMoves(myGame.Board, myGame.Players[0].Tiles)[0]
+		assert.Greater(t, solution.Spread, greedy.Score.Total+2*28-1)
+		last := solution.Turns[len(solution.Turns)-1]
+		assert.Equal(t, myGame.Players[0], last.Player)
+		assert.Equal(t, solution.Spread, sumTurnScores(solution, myGame.Players[0])+2*28)
+	})
+
+	t.Run("A solution is found despite the time limit", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S", "E", "T"}, []string{"Q", "Z", "X"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		solution, err := myGame.SolveEndgame(generator, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, solution.Depth)
+		assert.False(t, solution.Complete)
+		assert.Len(t, solution.Turns, 1)
+	})
+
+	t.Run("The snapshot is solved without the game", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S"}, []string{"Q", "Z"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		endgame, err := myGame.Endgame()
+		assert.NoError(t, err)
+		// Changes to the game after the snapshot are not seen by the solver
+		myGame.Players[0].Tiles = nil
+		myGame.Board.RemoveTileByCoordinates(7, 7)
+		solution := endgame.Solve(generator, time.Second)
+		assert.True(t, solution.Complete)
+		assert.Equal(t, 6+2*20, solution.Spread)
+	})
+
+	t.Run("The end game needs an empty bag and two players", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		myGame.Bag.Tiles = []Tile{*newTestTile("E", 1)}
+		_, err := myGame.SolveEndgame(generator, time.Second)
+		assert.ErrorIs(t, err, ErrBagNotEmpty)
+		myGame.Bag.Tiles = nil
+		myGame.Players = append(myGame.Players, NewPlayer("Player 3"))
+		_, err = myGame.SolveEndgame(generator, time.Second)
+		assert.ErrorIs(t, err, ErrNotTwoPlayers)
+	})
+}
+
+// sumTurnScores returns the points the player scores more than the opponent in the turns of the solution
+func sumTurnScores(solution EndgameSolution, player *Player) int {
+	sum := 0
+	for _, turn := range solution.Turns {
+		if turn.Player == player {
+			sum += turn.Score
+		} else {
+			sum -= turn.Score
+		}
+	}
+	return sum
+}
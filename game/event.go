@@ -0,0 +1,261 @@
+package game
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+)
+
+// This represents the events of a game. Every change of the game is published as an event to the subscribers of the
+// game, which lets the GUI, network peers and loggers follow the game without reaching into it.
+
+// Event is a change of the game published to the subscribers of the game
+type Event interface {
+	fmt.Stringer
+}
+
+// Subscriber is called with the events of a game in the order they occurred
+type Subscriber func(event Event)
+
+type EventActions interface {
+	// Subscribe registers the subscriber for all future events of the game. Each subscriber is called on its own
+	// goroutine, so a slow subscriber neither blocks the game nor other subscribers. The returned function cancels the
+	// subscription.
+	Subscribe(subscriber Subscriber) (unsubscribe func())
+}
+
+// PlayerJoined is published when a player was added to the game
+type PlayerJoined struct {
+	Player *Player
+}
+
+func (event PlayerJoined) String() string {
+	return fmt.Sprintf("player '%s' joined", event.Player.Name)
+}
+
+// TurnChanged is published when it is the turn of another player, including the starting player
+type TurnChanged struct {
+	Player *Player
+}
+
+func (event TurnChanged) String() string {
+	return fmt.Sprintf("turn of player '%s'", event.Player.Name)
+}
+
+// TilePlaced is published when a player placed a tile on the board without playing it yet
+type TilePlaced struct {
+	Player *Player
+	X      int
+	Y      int
+	Tile   Tile
+}
+
+func (event TilePlaced) String() string {
+	return fmt.Sprintf("player '%s' placed '%s' on (%d, %d)", event.Player.Name, event.Tile.PlayedLetter(), event.X, event.Y)
+}
+
+// TileTakenBack is published when a tile which was placed but not played is taken back to the rack
+type TileTakenBack struct {
+	Player *Player
+	X      int
+	Y      int
+	Tile   Tile
+}
+
+func (event TileTakenBack) String() string {
+	return fmt.Sprintf("player '%s' took back '%s' from (%d, %d)", event.Player.Name, event.Tile.Letter, event.X, event.Y)
+}
+
+// MovePlayed is published when a player played tiles
+type MovePlayed struct {
+	Player *Player
+	// Move holds the tiles as placed on the board
+	Move  []Move
+	Score MoveScore
+}
+
+func (event MovePlayed) String() string {
+	return fmt.Sprintf("player '%s' played %s", event.Player.Name, event.Score)
+}
+
+// MovePending is published when a player played tiles which have to be accepted or challenged by another player
+type MovePending struct {
+	Player *Player
+	// Move holds the tiles as placed on the board
+	Move  []Move
+	Score MoveScore
+}
+
+func (event MovePending) String() string {
+	return fmt.Sprintf("player '%s' played %s, waiting to be accepted or challenged", event.Player.Name, event.Score)
+}
+
+// MoveChallenged is published when a player challenged the pending move of another player
+type MoveChallenged struct {
+	Challenger *Player
+	Player     *Player
+	Result     ChallengeResult
+}
+
+func (event MoveChallenged) String() string {
+	if event.Result.Phony {
+		return fmt.Sprintf("player '%s' successfully challenged the move of player '%s' (%s)",
+			event.Challenger.Name, event.Player.Name, strings.Join(wordStrings(event.Result.InvalidWords), ", "))
+	}
+	return fmt.Sprintf("player '%s' challenged the valid move of player '%s'", event.Challenger.Name, event.Player.Name)
+}
+
+// ScoreChanged is published when the score of a player changed
+type ScoreChanged struct {
+	Player *Player
+	Score  int
+}
+
+func (event ScoreChanged) String() string {
+	return fmt.Sprintf("player '%s' has %d points", event.Player.Name, event.Score)
+}
+
+// TilesDrawn is published when a player drew tiles from the bag
+type TilesDrawn struct {
+	Player *Player
+	Tiles  []Tile
+	// TilesInBag is the number of tiles left in the bag
+	TilesInBag int
+}
+
+func (event TilesDrawn) String() string {
+	return fmt.Sprintf("player '%s' drew %d tiles, %d left in the bag", event.Player.Name, len(event.Tiles), event.TilesInBag)
+}
+
+// TilesExchanged is published when a player exchanged tiles
+type TilesExchanged struct {
+	Player *Player
+	Count  int
+}
+
+func (event TilesExchanged) String() string {
+	return fmt.Sprintf("player '%s' exchanged %d tiles", event.Player.Name, event.Count)
+}
+
+// Passed is published when a player passed
+type Passed struct {
+	Player *Player
+}
+
+func (event Passed) String() string {
+	return fmt.Sprintf("player '%s' passed", event.Player.Name)
+}
+
+// TurnTakenBack is published when a committed turn was taken back
+type TurnTakenBack struct {
+	Turn Turn
+	// TilesInBag is the number of tiles in the bag after taking back the turn
+	TilesInBag int
+}
+
+func (event TurnTakenBack) String() string {
+	return fmt.Sprintf("took back %s of player '%s'", event.Turn.Kind, event.Turn.Player.Name)
+}
+
+// TurnRedone is published when a turn taken back was committed again
+type TurnRedone struct {
+	Turn Turn
+	// TilesInBag is the number of tiles in the bag after redoing the turn
+	TilesInBag int
+}
+
+func (event TurnRedone) String() string {
+	return fmt.Sprintf("redid %s of player '%s'", event.Turn.Kind, event.Turn.Player.Name)
+}
+
+// GameEnded is published when the game is finished
+type GameEnded struct {
+	Standings Standings
+}
+
+func (event GameEnded) String() string {
+	return fmt.Sprintf("game ended (%s): %s", event.Standings.Reason,
+		strings.ReplaceAll(event.Standings.String(), "\n", ", "))
+}
+
+// NewEventLogger returns a subscriber which logs all events with the given logger
+func NewEventLogger(logger *zap.Logger) Subscriber {
+	return func(event Event) {
+		logger.Info(event.String(), zap.String("event", fmt.Sprintf("%T", event)))
+	}
+}
+
+func (game *Game) Subscribe(subscriber Subscriber) func() {
+	subscription := newSubscription(subscriber)
+	game.subscriptionsMutex.Lock()
+	game.subscriptions = append(game.subscriptions, subscription)
+	game.subscriptionsMutex.Unlock()
+	return func() {
+		game.subscriptionsMutex.Lock()
+		defer game.subscriptionsMutex.Unlock()
+		for i, s := range game.subscriptions {
+			if s == subscription {
+				game.subscriptions = append(game.subscriptions[:i], game.subscriptions[i+1:]...)
+				break
+			}
+		}
+		subscription.close()
+	}
+}
+
+// publish hands the event to all subscribers without waiting for them
+func (game *Game) publish(event Event) {
+	game.subscriptionsMutex.Lock()
+	defer game.subscriptionsMutex.Unlock()
+	for _, subscription := range game.subscriptions {
+		subscription.push(event)
+	}
+}
+
+// subscription queues the events for a subscriber and delivers them on its own goroutine
+type subscription struct {
+	subscriber Subscriber
+	mutex      sync.Mutex
+	signal     *sync.Cond
+	queue      []Event
+	closed     bool
+}
+
+func newSubscription(subscriber Subscriber) *subscription {
+	s := &subscription{subscriber: subscriber}
+	s.signal = sync.NewCond(&s.mutex)
+	go s.deliver()
+	return s
+}
+
+func (s *subscription) push(event Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.queue = append(s.queue, event)
+	s.signal.Signal()
+}
+
+func (s *subscription) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	s.signal.Signal()
+}
+
+func (s *subscription) deliver() {
+	for {
+		s.mutex.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.signal.Wait()
+		}
+		if s.closed {
+			s.mutex.Unlock()
+			return
+		}
+		event := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mutex.Unlock()
+		s.subscriber(event)
+	}
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+)
+
+// subscribeEvents collects the strings of all events of the game except executed commands
+func subscribeEvents(myGame *Game) (chan Event, func()) {
+	events := make(chan Event, 100)
+	unsubscribe := myGame.Subscribe(func(event Event) {
+		if _, ok := event.(CommandExecuted); !ok {
+			events <- event
+		}
+	})
+	return events, unsubscribe
+}
+
+func TestEvents(t *testing.T) {
+	t.Run("Playing a move publishes its events in order", func(t *testing.T) {
+		myGame := newTestLobby("Player 1", "Player 2")
+		myGame.Lexicon = newTestDictionary("at")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		events, unsubscribe := subscribeEvents(myGame)
+		defer unsubscribe()
+		move := newMove(myGame.Board, "AT", 7, 7, Horizontal)
+		player.Tiles = append(player.Tiles[:RackSize-2], *move[0].Tile, *move[1].Tile)
+		for _, m := range move {
+			assert.NoError(t, myGame.Execute(PlaceTileCommand{Player: player, Move: m}))
+		}
+		assert.NoError(t, myGame.Execute(&PlayCommand{Player: player}))
+
+		assert.Equal(t, TilePlaced{Player: player, X: 7, Y: 7, Tile: *move[0].Tile}, receive(t, events))
+		assert.IsType(t, TilePlaced{}, receive(t, events))
+		played := receive(t, events).(MovePlayed)
+		assert.Equal(t, player, played.Player)
+		assert.Equal(t, 4, played.Score.Total)
+		assert.Equal(t, ScoreChanged{Player: player, Score: 4}, receive(t, events))
+		drawn := receive(t, events).(TilesDrawn)
+		assert.Len(t, drawn.Tiles, 2)
+		assert.Equal(t, len(myGame.Bag.Tiles), drawn.TilesInBag)
+		assert.Equal(t, TurnChanged{Player: myGame.CurrentPlayer}, receive(t, events))
+	})
+
+	t.Run("Taking back a turn publishes the restored state", func(t *testing.T) {
+		myGame := newTestLobby("Player 1", "Player 2")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		assert.NoError(t, myGame.Pass(player))
+		events, unsubscribe := subscribeEvents(myGame)
+		defer unsubscribe()
+		assert.NoError(t, myGame.Undo())
+		assert.Equal(t, "took back pass of player '"+player.Name+"'", receive(t, events).String())
+		assert.IsType(t, ScoreChanged{}, receive(t, events))
+		assert.IsType(t, ScoreChanged{}, receive(t, events))
+		assert.Equal(t, TurnChanged{Player: player}, receive(t, events))
+	})
+
+	t.Run("End of the game is published", func(t *testing.T) {
+		myGame := newTestLobby("Player 1")
+		assert.NoError(t, myGame.Start())
+		events, unsubscribe := subscribeEvents(myGame)
+		defer unsubscribe()
+		for i := 0; i < MaxScorelessTurns; i++ {
+			assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		}
+		var ended GameEnded
+		for event := receive(t, events); ; event = receive(t, events) {
+			if e, ok := event.(GameEnded); ok {
+				ended = e
+				break
+			}
+		}
+		assert.Equal(t, TooManyScoreless, ended.Standings.Reason)
+	})
+
+	t.Run("Event logger logs every event", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		logEvent := NewEventLogger(zap.New(core))
+		logEvent(Passed{Player: NewPlayer("Player 1")})
+		assert.Equal(t, 1, logs.Len())
+		assert.Equal(t, "player 'Player 1' passed", logs.All()[0].Message)
+		assert.Equal(t, "game.Passed", logs.All()[0].ContextMap()["event"])
+	})
+}
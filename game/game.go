@@ -1,19 +1,46 @@
 package game
 
 import (
+	"assets"
+	"errors"
 	"fmt"
 	"go.uber.org/zap"
 	"sort"
+	"sync"
+	"time"
 )
 
 type Game struct {
-	Board         *Board
-	Bag           *Bag
-	Players       []Player
+	Board *Board
+	Bag   *Bag
+	// Players in turn order
+	Players []*Player
+	// CurrentPlayer is the player whose turn it is, nil until the game is started
 	CurrentPlayer *Player
+	Phase         Phase
+	// ScorelessTurns counts the consecutive turns without points
+	ScorelessTurns int
+	// Result holds the final standings once the game is finished
+	Result *Standings
+	// History holds all committed turns in order
+	History     []Turn
+	undoneTurns []Turn
+	// Pending is the move waiting to be accepted or challenged, see ChallengeActions
+	Pending *PendingMove
+	// lostTurns holds the players who forfeit their next turn after losing a double challenge
+	lostTurns map[*Player]bool
 	// Temporary tiles move by the player
 	TemporaryMoves map[*Player][]Move
-	Dictionary     *Dictionary
+	// Lexicon the words of moves are validated against
+	Lexicon Lexicon
+	// LexiconID records the ID of the lexicon the game was created with, see LexiconActions
+	LexiconID string
+	// Rules the game is played with
+	Rules *RuleSet
+	// mutex serializes commands and views, see Execute
+	mutex              sync.RWMutex
+	subscriptions      []*subscription
+	subscriptionsMutex sync.Mutex
 }
 
 type Move struct {
@@ -23,132 +50,216 @@ type Move struct {
 }
 
 type GameActions interface {
-	// AddTemporaryMove plays the temporary tiles of the player and returns the score
+	// AddTemporaryMove adds a tile placed by the player to the temporary moves. A tile which is already part of the
+	// temporary moves is moved to the new position.
 	AddTemporaryMove(player *Player, move Move)
-	// RemoveTemporaryMove removes the temporary tiles of the player and returns the score
+	// RemoveTemporaryMove removes the temporary move of the tile from the temporary moves of the player
 	RemoveTemporaryMove(player *Player, move Move)
 	// ResetTemporaryMoves resets the temporary moves of the player
 	ResetTemporaryMoves(player *Player)
-	// PlayTemporaryMoves plays the temporary moves of the player and returns the score
-	PlayTemporaryMoves(player *Player) int
-	//CheckMove checks if the move of the player is valid. A move is a sequence of pairs of tiles along with coordinates
-	CheckMove(player *Player, move []Move) bool
-	// PullNewTilesFromBag pulls new tiles from the bag and adds them to the player's rack
+	// PlayTemporaryMoves plays the temporary moves of the player and returns the score broken down by words along with
+	// the result of the move check, which tells why the move was rejected. Afterward, the rack of the player is refilled
+	// and the turn passes to the next player. Unless the void challenge rule applies, words are not checked and the move
+	// is pending until another player accepts or challenges it.
+	PlayTemporaryMoves(player *Player) (MoveScore, MoveCheckResult)
+	// ScoreTemporaryMoves calculates the score the temporary moves of the player would achieve without playing them
+	ScoreTemporaryMoves(player *Player) MoveScore
+	// CheckMove checks if the move of the player is valid. A move is a sequence of pairs of tiles along with coordinates.
+	// All words formed by the move, the main word as well as every cross word, have to be in the dictionary. Every
+	// violated rule is listed in the result.
+	CheckMove(player *Player, move []Move) MoveCheckResult
+	// PullNewTilesFromBag pulls new tiles from the bag and adds them to the player's rack until it is full
 	PullNewTilesFromBag(player *Player) []Tile
+	// ExchangeTiles returns the given tiles of the player's rack to the bag and draws the same number of new tiles
+	// instead. Exchanging tiles is only allowed while the bag holds at least the exchange minimum of the rules and counts
+	// as the player's turn.
+	ExchangeTiles(player *Player, tiles []Tile) error
 }
 
-func (game *Game) PlayTemporaryMoves(player *Player) int {
+// ExchangeMinimum is the minimum number of tiles in the bag to allow exchanging tiles under the official rules
+const ExchangeMinimum = 7
+
+var (
+	ErrNoTilesSelected     = errors.New("no tiles selected")
+	ErrTilesNotInRack      = errors.New("tiles are not on the rack")
+	ErrNotEnoughTilesInBag = errors.New("not enough tiles in the bag")
+)
+
+func (game *Game) PlayTemporaryMoves(player *Player) (MoveScore, MoveCheckResult) {
+	if err := game.CheckTurn(player); err != nil {
+		zap.L().Debug("Cannot play temporary moves", zap.Error(err))
+		violation := NotYourTurn
+		if errors.Is(err, ErrMovePending) {
+			violation = ChallengePending
+		}
+		return MoveScore{}, MoveCheckResult{Violations: []RuleViolation{violation}}
+	}
 	// Check if the player has temporary moves
-	if game.TemporaryMoves[player] == nil {
+	if len(game.TemporaryMoves[player]) == 0 {
 		zap.L().Debug("Player has no temporary moves")
-		return 0
+		return MoveScore{}, MoveCheckResult{Violations: []RuleViolation{NoTilesPlaced}}
 	}
 	// Check if the move is valid
-	valid := game.CheckMove(player, game.TemporaryMoves[player])
-	if !valid {
-		zap.L().Debug("Cannot play temporary moves. Move is invalid")
-		return 0
+	result := game.CheckMove(player, game.TemporaryMoves[player])
+	if game.isChallengeable() {
+		return game.playPendingMove(player, result)
 	}
-	score := game.playMove(player, game.TemporaryMoves[player])
-	zap.L().Debug(fmt.Sprintf("Player '%s' played temporary moves and scored %d points", player.Name, score))
-	return score
+	if !result.IsValid {
+		zap.L().Debug("Cannot play temporary moves. Move is invalid", zap.Stringer("reasons", result))
+		return MoveScore{}, result
+	}
+	before := game.captureState(player)
+	score, move := game.playMove(player, game.TemporaryMoves[player])
+	zap.L().Debug(fmt.Sprintf("Player '%s' played temporary moves and scored %s", player.Name, score))
+	drawn := game.PullNewTilesFromBag(player)
+	game.endTurn(score.Total)
+	game.recordTurn(Turn{Kind: PlayTurn, Player: player, Move: move, Score: score, Drawn: drawn}, before)
+	return score, result
 }
 
-func (game *Game) CheckMove(player *Player, move []Move) bool {
+func (game *Game) ScoreTemporaryMoves(player *Player) MoveScore {
+	return game.Board.ScoreMove(game.TemporaryMoves[player])
+}
+
+func (game *Game) CheckMove(player *Player, move []Move) MoveCheckResult {
 	zap.L().Debug(fmt.Sprintf("Checking move of player '%s'", player.Name))
-	// First check which direction the move is in by comparing the first and the last in x as well as y direction.
-	// In case x of the last and the first tile are the same, the move is in y direction and vice versa
-	direction := "x"
-	if move[0].X == move[len(move)-1].X {
-		direction = "y"
-	} else if move[0].Y == move[len(move)-1].Y {
-		direction = "x"
-	} else {
-		zap.L().Debug("\tMove is invalid. Tiles are not in a straight line")
-		return false
-	}
-	zap.L().Debug("\tMove is in direction", zap.String("direction", direction))
-	// Iterate in the direction of the move from the first to the last ordinate in the direction of the move.
-	// In case there is a gap between two consecutive moves, which is indicated if the difference between the current
-	// and the previous ordinate is greater than 1, try to take the "missing" tile from the board. If the tile is not
-	// on the board, the move is invalid.
-	word := ""
-	for i := 0; i < len(move); i++ {
-		if direction == "x" {
-			if i > 0 && move[i].X-move[i-1].X > 1 {
-				if game.Board.IsFieldEmpty(move[i].X-1, move[i].Y) {
-					zap.L().Debug("\tMove is invalid. There is a gap between two consecutive tiles")
-					return false
-				}
-				word += game.Board.Fields[move[i].X-1][move[i].Y].Tile.Letter
-				continue
-			}
-			word += move[i].Tile.Letter
-		} else {
-			if i > 0 && move[i].Y-move[i-1].Y > 1 {
-				if game.Board.IsFieldEmpty(move[i].X, move[i].Y-1) {
-					zap.L().Debug("\tMove is invalid. There is a gap between two consecutive tiles")
-					return false
-				}
-				word += game.Board.Fields[move[i].X][move[i].Y-1].Tile.Letter
-				continue
-			}
-			word += move[i].Tile.Letter
+	result := MoveCheckResult{
+		Violations:   make([]RuleViolation, 0),
+		Words:        make([]Word, 0),
+		InvalidWords: make([]Word, 0),
+	}
+	words, violations := game.Board.ValidatePlacement(move)
+	result.Violations = append(result.Violations, violations...)
+	if !player.HasTiles(move) {
+		result.Violations = append(result.Violations, TilesNotInRack)
+	}
+	if len(words) == 0 {
+		return result
+	}
+	result.Words = words
+	// Check if all words are in the dictionary
+	for _, word := range words {
+		zap.L().Debug("\tWord is", zap.Stringer("word", word))
+		// Letters of several runes have to be played with their own tile, e.g. the Spanish "CH"
+		if !game.Lexicon.IsWord(word.String()) || !game.Rules.SpellsWord(word) {
+			result.InvalidWords = append(result.InvalidWords, word)
 		}
 	}
-	zap.L().Debug("\tWord is", zap.String("word", word))
-	// Check if the word is in the dictionary
-	if !game.Dictionary.IsWord(word) {
-		zap.L().Debug("\tMove is invalid. Word is not in the dictionary")
-		return false
+	if len(result.InvalidWords) > 0 {
+		result.Violations = append(result.Violations, UnknownWords)
 	}
-	zap.L().Debug("\tMove is valid")
-	return true
+	result.IsValid = len(result.Violations) == 0
+	zap.L().Debug("\tChecked move", zap.Bool("valid", result.IsValid), zap.Stringer("reasons", result))
+	return result
+}
+
+// playMove places the tiles of the move on the board and credits the score to the player. The move is returned with the
+// tiles as placed on the board.
+func (game *Game) playMove(player *Player, move []Move) (MoveScore, []Move) {
+	// Score the move before its tiles are on the board to tell placed tiles from existing ones
+	score := game.Board.ScoreMove(move)
+	placed := game.placeMove(player, move)
+	game.creditMove(player, placed, score)
+	return score, placed
 }
 
-func (game *Game) playMove(player *Player, move []Move) int {
-	score := 0
+// placeMove moves the tiles of the move from the rack of the player to the board and returns the move with the tiles as
+// placed on the board
+func (game *Game) placeMove(player *Player, move []Move) []Move {
+	// The tiles of the move may point into the rack of the player, so the board gets its own copies before the tiles
+	// are removed from the rack
+	tiles := make([]Tile, len(move))
+	for i, m := range move {
+		tiles[i] = *m.Tile
+	}
 	// Iterate tiles of move and place them on the board
-	for _, move := range move {
-		game.Board.PlaceTile(move.Tile, move.X, move.Y)
-		score += move.Tile.LetterScore
+	placed := make([]Move, len(move))
+	for i, m := range move {
+		game.Board.PlaceTile(&tiles[i], m.X, m.Y)
+		placed[i] = Move{X: m.X, Y: m.Y, Tile: &tiles[i]}
 	}
 	// Remove the tiles from the player's rack
-	for _, move := range move {
-		player.RemoveTile(*move.Tile)
+	for _, tile := range tiles {
+		player.RemoveTile(tile)
 	}
-	game.ResetTemporaryMoves(player)
-	player.Score += score
-	return score
+	// The tiles are on the board now, so blank tiles keep their assigned letter
+	game.TemporaryMoves[player] = []Move{}
+	return placed
+}
+
+// creditMove adds the score of the move placed on the board to the score of the player
+func (game *Game) creditMove(player *Player, placed []Move, score MoveScore) {
+	player.Score += score.Total
+	game.publish(MovePlayed{Player: player, Move: placed, Score: score})
+	game.publish(ScoreChanged{Player: player, Score: player.Score})
 }
 
 func (game *Game) PullNewTilesFromBag(player *Player) []Tile {
 	numberOfCurrentTiles := len(player.Tiles)
-	if numberOfCurrentTiles == 7 {
-		zap.L().Debug(fmt.Sprintf("Player '%s' already has 7 tiles", player.Name))
+	if numberOfCurrentTiles >= game.Rules.RackSize {
+		zap.L().Debug(fmt.Sprintf("Player '%s' already has %d tiles", player.Name, game.Rules.RackSize))
 		return nil
 	}
 	// Pull new tiles from the bag
-	newTiles := game.Bag.TakeTiles(7 - numberOfCurrentTiles)
+	newTiles := game.Bag.TakeTiles(game.Rules.RackSize - numberOfCurrentTiles)
 
 	// Add the new tiles to the player's rack
 	player.Tiles = append(player.Tiles, newTiles...)
 	zap.L().Debug(fmt.Sprintf("Player '%s' pulled %d new tiles", player.Name, len(newTiles)))
+	game.publish(TilesDrawn{Player: player, Tiles: newTiles, TilesInBag: len(game.Bag.Tiles)})
 	return newTiles
 }
 
+func (game *Game) ExchangeTiles(player *Player, tiles []Tile) error {
+	if err := game.CheckTurn(player); err != nil {
+		return err
+	}
+	if len(tiles) == 0 {
+		return ErrNoTilesSelected
+	}
+	if len(game.Bag.Tiles) < game.Rules.ExchangeMinimum {
+		return ErrNotEnoughTilesInBag
+	}
+	if !player.HasRackTiles(tiles) {
+		return ErrTilesNotInRack
+	}
+	game.ResetTemporaryMoves(player)
+	before := game.captureState(player)
+	// The given tiles may share memory with the rack, which changes while removing them
+	tiles = append([]Tile{}, tiles...)
+	for _, tile := range tiles {
+		player.RemoveTile(tile)
+	}
+	newTiles := game.Bag.ExchangeTiles(tiles)
+	player.Tiles = append(player.Tiles, newTiles...)
+	zap.L().Debug(fmt.Sprintf("Player '%s' exchanged %d tiles", player.Name, len(newTiles)))
+	game.publish(TilesExchanged{Player: player, Count: len(tiles)})
+	game.publish(TilesDrawn{Player: player, Tiles: newTiles, TilesInBag: len(game.Bag.Tiles)})
+	game.endTurn(0)
+	game.recordTurn(Turn{Kind: ExchangeTurn, Player: player, Drawn: newTiles, Returned: tiles}, before)
+	return nil
+}
+
 func (game *Game) AddTemporaryMove(player *Player, move Move) {
 	if game.TemporaryMoves[player] == nil {
 		game.TemporaryMoves[player] = []Move{}
 	}
+	// A tile can only be placed once, so a previous move of the same tile is replaced
+	for i, m := range game.TemporaryMoves[player] {
+		if m.Tile.ID == move.Tile.ID {
+			game.TemporaryMoves[player] = append(game.TemporaryMoves[player][:i], game.TemporaryMoves[player][i+1:]...)
+			break
+		}
+	}
 	game.TemporaryMoves[player] = append(game.TemporaryMoves[player], move)
 	zap.L().Debug(fmt.Sprintf(
 		"Player '%s' placed tile '%s' on position (%d, %d)",
 		player.Name,
-		move.Tile.Letter,
+		move.Tile.PlayedLetter(),
 		move.X,
 		move.Y,
 	))
+	game.publish(TilePlaced{Player: player, X: move.X, Y: move.Y, Tile: *move.Tile})
 	// Sort the temporary moves by x and y
 	sortTemporaryMovesByPosition(player, game)
 }
@@ -168,8 +279,9 @@ func (game *Game) RemoveTemporaryMove(player *Player, move Move) {
 	}
 	// Remove the move from the temporary moves
 	for i, m := range game.TemporaryMoves[player] {
-		if m == move {
+		if m.Tile.ID == move.Tile.ID {
 			game.TemporaryMoves[player] = append(game.TemporaryMoves[player][:i], game.TemporaryMoves[player][i+1:]...)
+			move.Tile.ResetAssignment()
 			zap.L().Debug(fmt.Sprintf(
 				"Player '%s' removed tile '%s' from from position (%d, %d)",
 				player.Name,
@@ -177,22 +289,97 @@ func (game *Game) RemoveTemporaryMove(player *Player, move Move) {
 				move.X,
 				move.Y,
 			))
+			game.publish(TileTakenBack{Player: player, X: m.X, Y: m.Y, Tile: *move.Tile})
+			break
 		}
 	}
 	sortTemporaryMovesByPosition(player, game)
 }
 
 func (game *Game) ResetTemporaryMoves(player *Player) {
+	// Blank tiles taken back to the rack lose their assigned letter
+	for _, move := range game.TemporaryMoves[player] {
+		move.Tile.ResetAssignment()
+		game.publish(TileTakenBack{Player: player, X: move.X, Y: move.Y, Tile: *move.Tile})
+	}
 	game.TemporaryMoves[player] = []Move{}
 }
 
-func NewGame() *Game {
+// GameOption configures a game created by NewGame
+type GameOption func(settings *gameSettings)
+
+type gameSettings struct {
+	seed     int64
+	rules    *RuleSet
+	language *Language
+	lexicon  Lexicon
+}
+
+// WithSeed makes the game draw its tiles and select the starting player with the given seed
+func WithSeed(seed int64) GameOption {
+	return func(settings *gameSettings) {
+		settings.seed = seed
+	}
+}
+
+// WithRuleSet makes the game use the given rules instead of the official rules
+func WithRuleSet(rules *RuleSet) GameOption {
+	return func(settings *gameSettings) {
+		settings.rules = rules
+	}
+}
+
+// WithLanguage makes the game use the dictionary of the language and its tiles instead of the tiles of the rules
+func WithLanguage(language *Language) GameOption {
+	return func(settings *gameSettings) {
+		settings.language = language
+	}
+}
+
+// WithLexicon makes the game validate words against the lexicon instead of the dictionary of its language
+func WithLexicon(lexicon Lexicon) GameOption {
+	return func(settings *gameSettings) {
+		settings.lexicon = lexicon
+	}
+}
+
+// NewGame creates a game in the lobby phase. It fails if the dictionary cannot be loaded.
+func NewGame(options ...GameOption) (*Game, error) {
+	settings := &gameSettings{
+		seed:  time.Now().UnixNano(),
+		rules: OfficialRules(),
+	}
+	for _, option := range options {
+		option(settings)
+	}
+	if settings.language != nil {
+		rules := *settings.rules
+		rules.TileSet = settings.language.TileSet
+		settings.rules = &rules
+	}
+	lexicon := settings.lexicon
+	if lexicon == nil {
+		var dictionary *Dictionary
+		var err error
+		if settings.language != nil {
+			dictionary, err = settings.language.LoadDictionary()
+		} else {
+			dictionary, err = LoadDictionaryFromFS(assets.FS(), DictionaryDirectory+"/en.dawg", settings.rules.TileSet)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lexicon = dictionary
+	}
+	bag := newBagWithSeed(settings.rules, settings.seed)
 	return &Game{
-		Board:          NewBoard(),
-		Bag:            NewBag(),
-		Players:        []Player{},
+		Board:          NewBoardWithRuleSet(settings.rules),
+		Bag:            bag,
+		Players:        []*Player{},
+		Phase:          PhaseLobby,
 		TemporaryMoves: map[*Player][]Move{},
-		Dictionary:     NewDictionaryFromDAWG("../assets/dicts/en.dawg"),
-		CurrentPlayer:  NewPlayerWithRandomName(),
-	}
+		Lexicon:        lexicon,
+		LexiconID:      lexicon.ID(),
+		Rules:          settings.rules,
+	}, nil
 }
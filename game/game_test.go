@@ -0,0 +1,248 @@
+package game
+
+import (
+	"github.com/smhanov/dawg"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func newTestTile(letter string, letterScore int) *Tile {
+	return NewTile(letter, letterScore)
+}
+
+func newTestDictionary(words ...string) *Dictionary {
+	sorted := make([]string, len(words))
+	for i, word := range words {
+		sorted[i] = strings.ToLower(word)
+	}
+	sort.Strings(sorted)
+	builder := dawg.New()
+	for _, word := range sorted {
+		builder.Add(word)
+	}
+	dictionary := &Dictionary{WordFinder: builder.Finish(), TileSet: EnglishTileSet(), Name: "test"}
+	dictionary.initWordsStats()
+	return dictionary
+}
+
+// newTestGame creates a game in progress with a single player and an empty rack
+func newTestGame(words ...string) *Game {
+	player := NewPlayer("Player 1")
+	return &Game{
+		Board:          NewBoard(),
+		Bag:            NewBag(),
+		Players:        []*Player{player},
+		CurrentPlayer:  player,
+		Phase:          PhaseInProgress,
+		TemporaryMoves: map[*Player][]Move{},
+		Lexicon:        newTestDictionary(words...),
+		Rules:          OfficialRules(),
+	}
+}
+
+// newTestGameWithRacks creates a game of two players holding the racks with the board word placed horizontally from
+// (6, 7). The bag is empty, so the racks hold all tiles left in the game.
+func newTestGameWithRacks(words []string, boardWord string, rack []string, opponentRack []string) *Game {
+	myGame := newTestGame(words...)
+	player, opponent := NewPlayer("Player 1"), NewPlayer("Player 2")
+	myGame.Players = []*Player{player, opponent}
+	myGame.CurrentPlayer = player
+	myGame.Bag.Tiles = nil
+	placeWord(myGame.Board, boardWord, 6, 7, Horizontal)
+	for _, letter := range rack {
+		player.Tiles = append(player.Tiles, *newTestTile(letter, LetterScores[letter]))
+	}
+	for _, letter := range opponentRack {
+		opponent.Tiles = append(opponent.Tiles, *newTestTile(letter, LetterScores[letter]))
+	}
+	return myGame
+}
+
+// placeWord puts the letters of the word on the board starting at the given coordinates
+func placeWord(board *Board, word string, x int, y int, direction Direction) {
+	dx, dy := direction.Step()
+	for i, letter := range word {
+		board.PlaceTile(newTestTile(string(letter), LetterScores[string(letter)]), x+i*dx, y+i*dy)
+	}
+}
+
+// newMove creates a move placing the letters of the word starting at the given coordinates, skipping occupied fields
+func newMove(board *Board, word string, x int, y int, direction Direction) []Move {
+	dx, dy := direction.Step()
+	move := make([]Move, 0)
+	for _, letter := range word {
+		for field, ok := board.GetField(x, y); ok && field.Tile != nil; field, ok = board.GetField(x, y) {
+			x, y = x+dx, y+dy
+		}
+		move = append(move, Move{X: x, Y: y, Tile: newTestTile(string(letter), LetterScores[string(letter)])})
+		x, y = x+dx, y+dy
+	}
+	return move
+}
+
+// checkMove hands the tiles of the move to the current player and checks the move
+func checkMove(myGame *Game, move []Move) MoveCheckResult {
+	for _, m := range move {
+		myGame.CurrentPlayer.Tiles = append(myGame.CurrentPlayer.Tiles, *m.Tile)
+	}
+	return myGame.CheckMove(myGame.CurrentPlayer, move)
+}
+
+func TestCheckMove(t *testing.T) {
+	t.Run("Main word and cross words are formed", func(t *testing.T) {
+		myGame := newTestGame("cat", "at", "ta")
+		placeWord(myGame.Board, "CAT", 6, 7, Horizontal)
+		// Place "TA" below "AT" of "CAT" forming "AT" and "TA" vertically and "TA" horizontally
+		move := []Move{
+			{X: 7, Y: 8, Tile: newTestTile("T", 1)},
+			{X: 8, Y: 8, Tile: newTestTile("A", 1)},
+		}
+		words, ok := myGame.Board.FormedWords(move)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"TA", "AT", "TA"}, wordStrings(words))
+		assert.Equal(t, Horizontal, words[0].Direction)
+		assert.Equal(t, Vertical, words[1].Direction)
+		result := checkMove(myGame, move)
+		assert.True(t, result.IsValid)
+		assert.Empty(t, result.Violations)
+		assert.Empty(t, result.InvalidWords)
+	})
+
+	t.Run("Invalid cross word is reported", func(t *testing.T) {
+		myGame := newTestGame("cat", "at", "ta")
+		placeWord(myGame.Board, "CAT", 6, 7, Horizontal)
+		// "AT" hooks below "C" forming the invalid cross word "CA"
+		move := []Move{
+			{X: 6, Y: 8, Tile: newTestTile("A", 1)},
+			{X: 7, Y: 8, Tile: newTestTile("T", 1)},
+		}
+		result := checkMove(myGame, move)
+		assert.False(t, result.IsValid)
+		assert.Equal(t, []RuleViolation{UnknownWords}, result.Violations)
+		assert.Equal(t, []string{"CA"}, wordStrings(result.InvalidWords))
+	})
+
+	t.Run("Gap is filled by tiles on the board", func(t *testing.T) {
+		myGame := newTestGame("cats")
+		placeWord(myGame.Board, "A", 7, 7, Vertical)
+		move := newMove(myGame.Board, "CTS", 6, 7, Horizontal)
+		words, ok := myGame.Board.FormedWords(move)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"CATS"}, wordStrings(words))
+		assert.False(t, words[0].Tiles[1].Placed)
+	})
+
+	t.Run("Single tile forms a vertical word", func(t *testing.T) {
+		myGame := newTestGame("at")
+		placeWord(myGame.Board, "A", 7, 7, Horizontal)
+		move := []Move{{X: 7, Y: 8, Tile: newTestTile("T", 1)}}
+		direction, ok := myGame.Board.MoveDirection(move)
+		assert.True(t, ok)
+		assert.Equal(t, Vertical, direction)
+		assert.True(t, checkMove(myGame, move).IsValid)
+	})
+
+	t.Run("Tiles with a gap are rejected", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		move := []Move{
+			{X: 6, Y: 7, Tile: newTestTile("C", 3)},
+			{X: 8, Y: 7, Tile: newTestTile("T", 1)},
+		}
+		_, ok := myGame.Board.FormedWords(move)
+		assert.False(t, ok)
+		assert.Equal(t, []RuleViolation{MissesCenter, NotContiguous}, checkMove(myGame, move).Violations)
+	})
+
+	t.Run("Tiles not in a straight line are rejected", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		move := []Move{
+			{X: 6, Y: 7, Tile: newTestTile("C", 3)},
+			{X: 7, Y: 7, Tile: newTestTile("A", 1)},
+			{X: 7, Y: 8, Tile: newTestTile("T", 1)},
+		}
+		assert.Equal(t, []RuleViolation{NotInLine}, checkMove(myGame, move).Violations)
+	})
+
+	t.Run("Tiles not on the rack are rejected", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		move := newMove(myGame.Board, "CAT", 6, 7, Horizontal)
+		myGame.CurrentPlayer.Tiles = append(myGame.CurrentPlayer.Tiles, *move[0].Tile, *move[1].Tile)
+		result := myGame.CheckMove(myGame.CurrentPlayer, move)
+		assert.False(t, result.IsValid)
+		assert.Equal(t, []RuleViolation{TilesNotInRack}, result.Violations)
+	})
+
+	t.Run("Every violated rule is reported", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		move := newMove(myGame.Board, "CTA", 0, 0, Horizontal)
+		result := myGame.CheckMove(myGame.CurrentPlayer, move)
+		assert.Equal(t, []RuleViolation{MissesCenter, TilesNotInRack, UnknownWords}, result.Violations)
+		assert.Equal(t, "first move does not cover the center star; tiles are not on the rack; "+
+			"word is not in the dictionary (CTA)", result.String())
+	})
+}
+
+func TestBlankTiles(t *testing.T) {
+	t.Run("Blank tile is validated with its assigned letter", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		move := newMove(myGame.Board, "C*T", 6, 7, Horizontal)
+		assert.Equal(t, []RuleViolation{BlankNotAssigned}, checkMove(myGame, move).Violations)
+		assert.True(t, move[1].Tile.AssignLetter("a", &myGame.Rules.TileSet))
+		assert.True(t, checkMove(myGame, move).IsValid)
+	})
+
+	t.Run("Blank tile scores zero", func(t *testing.T) {
+		board := NewBoard()
+		move := newMove(board, "C*T", 6, 7, Horizontal)
+		move[1].Tile.AssignLetter("A", &board.Rules.TileSet)
+		assert.Equal(t, "CAT 8 = 8", board.ScoreMove(move).String())
+	})
+
+	t.Run("Only blank tiles can be assigned a letter", func(t *testing.T) {
+		tiles := EnglishTileSet()
+		assert.False(t, newTestTile("A", 1).AssignLetter("B", &tiles))
+		assert.False(t, newTestTile(BlankLetter, 0).AssignLetter(BlankLetter, &tiles))
+		assert.False(t, newTestTile(BlankLetter, 0).AssignLetter("AB", &tiles))
+	})
+
+	t.Run("Assigned letter stays on the board", func(t *testing.T) {
+		myGame := newTestGame("cat", "cats")
+		move := newMove(myGame.Board, "C*T", 6, 7, Horizontal)
+		move[1].Tile.AssignLetter("A", &myGame.Rules.TileSet)
+		for _, m := range move {
+			myGame.CurrentPlayer.Tiles = append(myGame.CurrentPlayer.Tiles, *m.Tile)
+			myGame.AddTemporaryMove(myGame.CurrentPlayer, m)
+		}
+		score, result := myGame.PlayTemporaryMoves(myGame.CurrentPlayer)
+		assert.True(t, result.IsValid)
+		assert.Equal(t, 8, score.Total)
+		assert.Equal(t, "A", myGame.Board.Fields[7][7].Tile.PlayedLetter())
+		words, _ := myGame.Board.FormedWords(newMove(myGame.Board, "S", 9, 7, Horizontal))
+		assert.Equal(t, []string{"CATS"}, wordStrings(words))
+	})
+
+	t.Run("Taking back a blank tile removes its letter", func(t *testing.T) {
+		myGame := newTestGame()
+		move := newMove(myGame.Board, "*", 7, 7, Horizontal)
+		move[0].Tile.AssignLetter("A", &myGame.Rules.TileSet)
+		myGame.AddTemporaryMove(myGame.CurrentPlayer, move[0])
+		myGame.ResetTemporaryMoves(myGame.CurrentPlayer)
+		assert.Equal(t, BlankLetter, move[0].Tile.PlayedLetter())
+	})
+}
+
+func TestPlayTemporaryMoves(t *testing.T) {
+	t.Run("Tiles played from the rack stay on the board", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		player := myGame.CurrentPlayer
+		player.Tiles = []Tile{*NewTile("C", 3), *NewTile("A", 1), *NewTile("T", 1)}
+		for i, x := range []int{6, 7, 8} {
+			myGame.AddTemporaryMove(player, Move{X: x, Y: 7, Tile: &player.Tiles[i]})
+		}
+		_, result := myGame.PlayTemporaryMoves(player)
+		assert.True(t, result.IsValid, result.String())
+		assert.Equal(t, "CAT", myGame.Board.wordAt(6, 7, Horizontal, nil).String())
+	})
+}
@@ -0,0 +1,45 @@
+package game
+
+// This represents the extension point for move generators. Generators need a word graph of the lexicon and live outside
+// of this package, see package linguistic.
+
+// Placement is a legal move along with its score
+type Placement struct {
+	Move  []Move
+	Score MoveScore
+}
+
+// MoveGenerator enumerates the legal moves of a rack
+type MoveGenerator interface {
+	// GenerateMoves returns every legal placement of tiles of the rack on the board, sorted by descending score. The
+	// board is scored with its rules.
+	GenerateMoves(board *Board, rack []Tile) []Placement
+}
+
+// lexiconGenerator passes on the placements of a generator whose words are all in the lexicon
+type lexiconGenerator struct {
+	generator MoveGenerator
+	lexicon   Lexicon
+}
+
+// NewLexiconGenerator restricts the moves of the generator to the words of the lexicon. The word graph of a generator is
+// built from a single dictionary, while a game may validate words against another lexicon, e.g. the union of several
+// dictionaries. Words of the lexicon which are missing in the word graph are still not generated.
+func NewLexiconGenerator(generator MoveGenerator, lexicon Lexicon) MoveGenerator {
+	return lexiconGenerator{generator: generator, lexicon: lexicon}
+}
+
+func (generator lexiconGenerator) GenerateMoves(board *Board, rack []Tile) []Placement {
+	placements := generator.generator.GenerateMoves(board, rack)
+	legal := make([]Placement, 0, len(placements))
+	for _, placement := range placements {
+		words, ok := board.FormedWords(placement.Move)
+		for _, word := range words {
+			ok = ok && generator.lexicon.IsWord(word.String()) && board.Rules.SpellsWord(word)
+		}
+		if ok {
+			legal = append(legal, placement)
+		}
+	}
+	return legal
+}
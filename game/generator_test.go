@@ -0,0 +1,24 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLexiconGenerator(t *testing.T) {
+	t.Run("Moves with words missing in the lexicon are dropped", func(t *testing.T) {
+		myGame := newTestGameWithRacks([]string{"car", "cars", "care", "scare"}, "CAR", []string{"S", "E"}, nil)
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		placements := generator.GenerateMoves(myGame.Board, myGame.Players[0].Tiles)
+		restricted := NewLexiconGenerator(generator, NewWordList("test", []string{"car", "cars"}))
+		legal := restricted.GenerateMoves(myGame.Board, myGame.Players[0].Tiles)
+		assert.NotEmpty(t, legal)
+		assert.Less(t, len(legal), len(placements))
+		for _, placement := range legal {
+			words, _ := myGame.Board.FormedWords(placement.Move)
+			for _, word := range words {
+				assert.Contains(t, []string{"CAR", "CARS"}, word.String())
+			}
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// This represents hints for the current player. The moves of the rack are enumerated by a move generator and checked
+// against the lexicon of the game, as the generator may know other words. A hint can be previewed on the board as
+// temporary moves, which the player can play, change or take back like tiles placed by hand.
+
+var ErrHintOutdated = errors.New("the game changed since the hint was found")
+
+// Hint is a legal move of the rack of the current player
+type Hint struct {
+	Placement Placement
+	// Leave holds the tiles left on the rack after the move
+	Leave []Tile
+	// turns is the number of turns in the history when the hint was found
+	turns int
+}
+
+func (hint Hint) String() string {
+	leave := make([]string, len(hint.Leave))
+	for i, tile := range hint.Leave {
+		leave[i] = tile.Letter
+	}
+	return fmt.Sprintf("%s for %d, leaves '%s'", Decision{Move: hint.Placement.Move}, hint.Placement.Score.Total,
+		strings.Join(leave, ""))
+}
+
+type HintActions interface {
+	// Hints takes a snapshot of the board and the rack of the current player. The returned function finds the legal
+	// moves of the rack with the highest scores, at most count of them, which are enumerated by the generator. Hints
+	// must be called while viewing a shared game, see CommandActions, but the snapshot is searched without holding the
+	// game.
+	Hints(generator MoveGenerator, count int) (func() []Hint, error)
+}
+
+func (game *Game) Hints(generator MoveGenerator, count int) (func() []Hint, error) {
+	player := game.CurrentPlayer
+	if err := game.CheckTurn(player); err != nil {
+		return nil, err
+	}
+	// The moves are checked against a copy of the game, which only holds what CheckMove needs
+	snapshot := &Game{Board: game.Board.Copy(), Lexicon: game.Lexicon, Rules: game.Rules}
+	rack := &Player{Name: player.Name, Tiles: append([]Tile{}, player.Tiles...)}
+	turns := len(game.History)
+	return func() []Hint {
+		hints := make([]Hint, 0, count)
+		// The placements are sorted by descending score, so the first legal ones are the best
+		for _, placement := range generator.GenerateMoves(snapshot.Board, rack.Tiles) {
+			if len(hints) == count {
+				break
+			}
+			if !snapshot.CheckMove(rack, placement.Move).IsValid {
+				continue
+			}
+			hints = append(hints, Hint{Placement: placement, Leave: leftOnRack(rack.Tiles, placement.Move), turns: turns})
+		}
+		return hints
+	}, nil
+}
+
+// PreviewHintCommand places the tiles of the hint as the temporary moves of the player without playing them. Tiles
+// placed before are taken back to the rack. Hints found before the last turn was played or taken back are rejected.
+type PreviewHintCommand struct {
+	Player *Player
+	Hint   Hint
+}
+
+func (command PreviewHintCommand) Apply(game *Game) error {
+	if err := game.CheckTurn(command.Player); err != nil {
+		return err
+	}
+	if len(game.History) != command.Hint.turns {
+		return ErrHintOutdated
+	}
+	if !command.Player.HasTiles(command.Hint.Placement.Move) {
+		return ErrTilesNotInRack
+	}
+	game.ResetTemporaryMoves(command.Player)
+	for _, move := range command.Hint.Placement.Move {
+		// The temporary moves refer to the tiles on the rack, so blanks keep their letters until they are taken back
+		for i := range command.Player.Tiles {
+			if tile := &command.Player.Tiles[i]; tile.ID == move.Tile.ID {
+				tile.AssignedLetter = move.Tile.AssignedLetter
+				game.AddTemporaryMove(command.Player, Move{X: move.X, Y: move.Y, Tile: tile})
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHints(t *testing.T) {
+	words := []string{"car", "cars", "care", "cares", "scare", "ta", "at", "eta"}
+
+	t.Run("The best legal moves are returned with their leaves", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S", "E", "T"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		findHints, err := myGame.Hints(generator, 3)
+		assert.NoError(t, err)
+		hints := findHints()
+		assert.Len(t, hints, 3)
+		for i, hint := range hints {
+			assert.True(t, myGame.CheckMove(myGame.CurrentPlayer, hint.Placement.Move).IsValid)
+			assert.Len(t, hint.Leave, 3-len(hint.Placement.Move))
+			if i > 0 {
+				assert.LessOrEqual(t, hint.Placement.Score.Total, hints[i-1].Placement.Score.Total)
+			}
+		}
+		best := generator.GenerateMoves(myGame.Board, myGame.CurrentPlayer.Tiles)[0]
+		assert.Equal(t, best.Score.Total, hints[0].Placement.Score.Total)
+	})
+
+	t.Run("Moves of words the game does not know are left out", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S", "E", "T"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: newTestDictionary(append(words, "cart", "carts")...)}
+		findHints, err := myGame.Hints(generator, 100)
+		assert.NoError(t, err)
+		hints := findHints()
+		assert.NotEmpty(t, hints)
+		for _, hint := range hints {
+			assert.True(t, myGame.CheckMove(myGame.CurrentPlayer, hint.Placement.Move).IsValid, hint.String())
+		}
+		assert.Less(t, len(hints), len(generator.GenerateMoves(myGame.Board, myGame.CurrentPlayer.Tiles)))
+	})
+
+	t.Run("The snapshot is searched without the game", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S", "E", "T"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		findHints, err := myGame.Hints(generator, 100)
+		assert.NoError(t, err)
+		expected := len(generator.GenerateMoves(myGame.Board, myGame.CurrentPlayer.Tiles))
+		// Changes to the game after the snapshot are not seen while searching
+		myGame.CurrentPlayer.Tiles = nil
+		myGame.Board.RemoveTileByCoordinates(7, 7)
+		assert.Len(t, findHints(), expected)
+	})
+
+	t.Run("Only the current player gets hints", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		myGame.Phase = PhaseFinished
+		_, err := myGame.Hints(generator, 3)
+		assert.Error(t, err)
+	})
+}
+
+func TestPreviewHintCommand(t *testing.T) {
+	words := []string{"car", "cars", "care", "cares", "scare"}
+
+	t.Run("The tiles of the rack are placed without playing them", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S", "E"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		player := myGame.CurrentPlayer
+		findHints, err := myGame.Hints(generator, 10)
+		assert.NoError(t, err)
+		hints := findHints()
+		var hint Hint
+		for _, h := range hints {
+			if len(h.Placement.Move) == 2 {
+				hint = h
+				break
+			}
+		}
+		assert.Len(t, hint.Placement.Move, 2)
+		myGame.AddTemporaryMove(player, Move{X: 0, Y: 0, Tile: &player.Tiles[1]})
+		assert.NoError(t, myGame.Execute(PreviewHintCommand{Player: player, Hint: hint}))
+		moves := myGame.TemporaryMoves[player]
+		assert.Len(t, moves, 2)
+		for _, move := range moves {
+			assert.True(t, move.Tile == &player.Tiles[0] || move.Tile == &player.Tiles[1])
+		}
+		assert.Len(t, player.Tiles, 2)
+		assert.Nil(t, myGame.Board.Fields[moves[0].X][moves[0].Y].Tile)
+		score, result := myGame.PlayTemporaryMoves(player)
+		assert.True(t, result.IsValid, result.String())
+		assert.Equal(t, hint.Placement.Score.Total, score.Total)
+	})
+
+	t.Run("Hints found before the last turn are rejected", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		player := myGame.CurrentPlayer
+		findHints, err := myGame.Hints(generator, 1)
+		assert.NoError(t, err)
+		hints := findHints()
+		assert.Len(t, hints, 1)
+		assert.NoError(t, myGame.Pass(player))
+		assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		err = myGame.Execute(PreviewHintCommand{Player: player, Hint: hints[0]})
+		assert.ErrorIs(t, err, ErrHintOutdated)
+	})
+
+	t.Run("Tiles which are not on the rack are rejected", func(t *testing.T) {
+		myGame := newTestGameWithRacks(words, "CAR", []string{"S"}, []string{"Q"})
+		generator := bruteForceGenerator{lexicon: myGame.Lexicon}
+		findHints, err := myGame.Hints(generator, 1)
+		assert.NoError(t, err)
+		hints := findHints()
+		assert.Len(t, hints, 1)
+		myGame.CurrentPlayer.Tiles = []Tile{*newTestTile("S", 1)}
+		err = myGame.Execute(PreviewHintCommand{Player: myGame.CurrentPlayer, Hint: hints[0]})
+		assert.ErrorIs(t, err, ErrTilesNotInRack)
+		assert.Empty(t, myGame.TemporaryMoves[myGame.CurrentPlayer])
+	})
+}
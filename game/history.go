@@ -0,0 +1,208 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+// This represents the log of committed turns of a game, which allows taking back turns and redoing them
+
+type TurnKind int
+
+const (
+	PlayTurn      TurnKind = iota // The player placed tiles on the board
+	PassTurn                      // The player passed
+	ExchangeTurn                  // The player exchanged tiles
+	WithdrawnTurn                 // The move of the player was successfully challenged and taken back
+)
+
+func (kind TurnKind) String() string {
+	switch kind {
+	case PlayTurn:
+		return "play"
+	case PassTurn:
+		return "pass"
+	case ExchangeTurn:
+		return "exchange"
+	case WithdrawnTurn:
+		return "withdrawn move"
+	}
+	return fmt.Sprintf("turn kind %d", int(kind))
+}
+
+// Turn is a committed turn of a player
+type Turn struct {
+	Kind   TurnKind
+	Player *Player
+	// Move holds the tiles placed on the board, empty unless tiles were played
+	Move []Move
+	// Score of the move broken down into the formed words
+	Score      MoveScore
+	RackBefore []Tile
+	RackAfter  []Tile
+	// Drawn holds the tiles drawn from the bag at the end of the turn
+	Drawn []Tile
+	// Returned holds the tiles put back into the bag by an exchange
+	Returned []Tile
+	// Withdrawn holds the tiles of a phony taken back from the board after a challenge
+	Withdrawn []Move
+	// Challenger is the player who challenged the move, nil if the move was not challenged
+	Challenger *Player
+	// Forfeited holds the players who lost a double challenge and were skipped right after the turn
+	Forfeited []*Player
+	before    turnState
+	after     turnState
+}
+
+// turnState is the state of the game around a turn needed to take the turn back or to redo it
+type turnState struct {
+	currentPlayer  *Player
+	phase          Phase
+	scorelessTurns int
+	result         *Standings
+	scores         []int
+	rack           []Tile
+	bag            []Tile
+	// draws is the number of values drawn from the random source of the bag
+	draws     int64
+	lostTurns map[*Player]bool
+}
+
+var (
+	ErrNothingToUndo = errors.New("no turn to take back")
+	ErrNothingToRedo = errors.New("no turn to redo")
+)
+
+type HistoryActions interface {
+	// Undo takes back the last committed turn
+	Undo() error
+	// Redo commits the last turn taken back again. Committing a new turn discards all turns taken back.
+	Redo() error
+	// CanUndo checks if there is a turn to take back
+	CanUndo() bool
+	// CanRedo checks if there is a turn to redo
+	CanRedo() bool
+}
+
+func (game *Game) Undo() error {
+	if game.Pending != nil {
+		return ErrMovePending
+	}
+	if !game.CanUndo() {
+		return ErrNothingToUndo
+	}
+	turn := game.History[len(game.History)-1]
+	for _, m := range turn.Move {
+		game.Board.RemoveTileByCoordinates(m.X, m.Y)
+	}
+	game.restoreState(turn.Player, turn.before)
+	game.History = game.History[:len(game.History)-1]
+	game.undoneTurns = append(game.undoneTurns, turn)
+	zap.L().Debug(fmt.Sprintf("Took back %s of player '%s'", turn.Kind, turn.Player.Name))
+	game.publish(TurnTakenBack{Turn: turn, TilesInBag: len(game.Bag.Tiles)})
+	game.publishRestoredState()
+	return nil
+}
+
+func (game *Game) Redo() error {
+	if game.Pending != nil {
+		return ErrMovePending
+	}
+	if !game.CanRedo() {
+		return ErrNothingToRedo
+	}
+	turn := game.undoneTurns[len(game.undoneTurns)-1]
+	for _, m := range turn.Move {
+		game.Board.PlaceTile(m.Tile, m.X, m.Y)
+	}
+	game.restoreState(turn.Player, turn.after)
+	game.undoneTurns = game.undoneTurns[:len(game.undoneTurns)-1]
+	game.History = append(game.History, turn)
+	zap.L().Debug(fmt.Sprintf("Redid %s of player '%s'", turn.Kind, turn.Player.Name))
+	game.publish(TurnRedone{Turn: turn, TilesInBag: len(game.Bag.Tiles)})
+	game.publishRestoredState()
+	return nil
+}
+
+func (game *Game) CanUndo() bool {
+	return len(game.History) > 0
+}
+
+func (game *Game) CanRedo() bool {
+	return len(game.undoneTurns) > 0
+}
+
+// recordTurn appends the committed turn to the history. The state before the turn has to be captured before any
+// changes were made. Players who forfeit their turn are skipped as part of the turn, so undo and redo treat both as a
+// single step.
+func (game *Game) recordTurn(turn Turn, before turnState) {
+	for game.Phase == PhaseInProgress && game.lostTurns[game.CurrentPlayer] {
+		turn.Forfeited = append(turn.Forfeited, game.forfeitTurn())
+	}
+	turn.before = before
+	turn.after = game.captureState(turn.Player)
+	turn.RackBefore = before.rack
+	turn.RackAfter = turn.after.rack
+	game.History = append(game.History, turn)
+	game.undoneTurns = nil
+}
+
+func (game *Game) captureState(player *Player) turnState {
+	lostTurns := make(map[*Player]bool, len(game.lostTurns))
+	for p, lost := range game.lostTurns {
+		lostTurns[p] = lost
+	}
+	scores := make([]int, len(game.Players))
+	for i, p := range game.Players {
+		scores[i] = p.Score
+	}
+	return turnState{
+		currentPlayer:  game.CurrentPlayer,
+		phase:          game.Phase,
+		scorelessTurns: game.ScorelessTurns,
+		result:         game.Result,
+		scores:         scores,
+		rack:           append([]Tile{}, player.Tiles...),
+		bag:            append([]Tile{}, game.Bag.Tiles...),
+		draws:          game.Bag.draws(),
+		lostTurns:      lostTurns,
+	}
+}
+
+func (game *Game) restoreState(player *Player, state turnState) {
+	game.CurrentPlayer = state.currentPlayer
+	game.Phase = state.phase
+	game.ScorelessTurns = state.scorelessTurns
+	game.Result = state.result
+	for i, p := range game.Players {
+		p.Score = state.scores[i]
+	}
+	player.Tiles = append([]Tile{}, state.rack...)
+	// Blank tiles back on the rack lose their assigned letter
+	for i := range player.Tiles {
+		player.Tiles[i].ResetAssignment()
+	}
+	game.Bag.Tiles = append([]Tile{}, state.bag...)
+	game.Bag.rewind(state.draws)
+	game.lostTurns = make(map[*Player]bool, len(state.lostTurns))
+	for p, lost := range state.lostTurns {
+		game.lostTurns[p] = lost
+	}
+	for p := range game.TemporaryMoves {
+		game.ResetTemporaryMoves(p)
+	}
+}
+
+// publishRestoredState publishes the scores and the current player after taking back or redoing a turn
+func (game *Game) publishRestoredState() {
+	for _, player := range game.Players {
+		game.publish(ScoreChanged{Player: player, Score: player.Score})
+	}
+	switch game.Phase {
+	case PhaseInProgress:
+		game.publish(TurnChanged{Player: game.CurrentPlayer})
+	case PhaseFinished:
+		game.publish(GameEnded{Standings: *game.Result})
+	}
+}
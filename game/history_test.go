@@ -0,0 +1,129 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// playWord hands the tiles of the word to the current player and plays them
+func playWord(t *testing.T, myGame *Game, word string, x int, y int, direction Direction) MoveScore {
+	player := myGame.CurrentPlayer
+	move := newMove(myGame.Board, word, x, y, direction)
+	for _, m := range move {
+		player.Tiles = append(player.Tiles, *m.Tile)
+		myGame.AddTemporaryMove(player, m)
+	}
+	score, result := myGame.PlayTemporaryMoves(player)
+	assert.True(t, result.IsValid, result.String())
+	return score
+}
+
+func TestHistory(t *testing.T) {
+	t.Run("Played turn is recorded", func(t *testing.T) {
+		myGame := newTestGame("cat")
+		player := myGame.CurrentPlayer
+		score := playWord(t, myGame, "CAT", 6, 7, Horizontal)
+		assert.Len(t, myGame.History, 1)
+		turn := myGame.History[0]
+		assert.Equal(t, PlayTurn, turn.Kind)
+		assert.Equal(t, player, turn.Player)
+		assert.Equal(t, score, turn.Score)
+		assert.Len(t, turn.Move, 3)
+		assert.Len(t, turn.RackBefore, 3)
+		assert.Len(t, turn.Drawn, RackSize)
+		assert.Equal(t, turn.Drawn, turn.RackAfter)
+	})
+
+	t.Run("Undo takes back the last move and redo commits it again", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		myGame.Lexicon = newTestDictionary("cat", "cats")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		rack := append([]Tile{}, player.Tiles...)
+		bag := append([]Tile{}, myGame.Bag.Tiles...)
+		player.Tiles = nil
+		playWord(t, myGame, "CAT", 6, 7, Horizontal)
+		player.Tiles = rack
+		assert.NoError(t, myGame.Undo())
+		assert.True(t, myGame.Board.IsEmpty())
+		assert.Equal(t, 3, len(player.Tiles))
+		assert.Equal(t, bag, myGame.Bag.Tiles)
+		assert.Equal(t, 0, player.Score)
+		assert.True(t, myGame.IsTurn(player))
+		assert.Empty(t, myGame.History)
+		assert.Equal(t, ErrNothingToUndo, myGame.Undo())
+
+		assert.NoError(t, myGame.Redo())
+		assert.Equal(t, "CAT", myGame.Board.wordAt(6, 7, Horizontal, nil).String())
+		assert.Equal(t, 10, player.Score)
+		assert.Equal(t, myGame.History[0].Drawn, player.Tiles)
+		assert.False(t, myGame.IsTurn(player))
+		assert.Equal(t, ErrNothingToRedo, myGame.Redo())
+	})
+
+	t.Run("New turn discards turns taken back", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		assert.NoError(t, myGame.Undo())
+		assert.True(t, myGame.CanRedo())
+		assert.Equal(t, 0, myGame.ScorelessTurns)
+		assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		assert.False(t, myGame.CanRedo())
+		assert.Equal(t, PassTurn, myGame.History[0].Kind)
+	})
+
+	t.Run("Undo of an exchange returns the drawn tiles to the bag", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		rack := append([]Tile{}, player.Tiles...)
+		bag := append([]Tile{}, myGame.Bag.Tiles...)
+		assert.NoError(t, myGame.ExchangeTiles(player, player.Tiles[:2]))
+		turn := myGame.History[0]
+		assert.Equal(t, ExchangeTurn, turn.Kind)
+		assert.Equal(t, rack[:2], turn.Returned)
+		assert.Len(t, turn.Drawn, 2)
+		assert.NoError(t, myGame.Undo())
+		assert.Equal(t, rack, player.Tiles)
+		assert.Equal(t, bag, myGame.Bag.Tiles)
+	})
+
+	t.Run("Undo of the last turn reopens a finished game", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		for i := 0; i < MaxScorelessTurns; i++ {
+			assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		}
+		assert.Equal(t, PhaseFinished, myGame.Phase)
+		assert.NoError(t, myGame.Undo())
+		assert.Equal(t, PhaseInProgress, myGame.Phase)
+		assert.Nil(t, myGame.Result)
+		for _, player := range myGame.Players {
+			assert.Equal(t, 0, player.Score)
+		}
+		assert.NoError(t, myGame.Redo())
+		assert.Equal(t, PhaseFinished, myGame.Phase)
+		assert.NotNil(t, myGame.Result)
+	})
+
+	t.Run("Draws after an undo are the same as in a replay from the seed", func(t *testing.T) {
+		start := func() *Game {
+			myGame := newTestLobby("Alice", "Bob")
+			myGame.Bag = NewBagWithSeed(5)
+			assert.NoError(t, myGame.Start())
+			return myGame
+		}
+		myGame, replay := start(), start()
+		assert.NoError(t, myGame.ExchangeTiles(myGame.CurrentPlayer, myGame.CurrentPlayer.Tiles[:2]))
+		assert.NoError(t, myGame.Undo())
+		assert.NoError(t, myGame.ExchangeTiles(myGame.CurrentPlayer, myGame.CurrentPlayer.Tiles[2:5]))
+		assert.NoError(t, replay.ExchangeTiles(replay.CurrentPlayer, replay.CurrentPlayer.Tiles[2:5]))
+		assert.Equal(t, replay.History[0].Drawn, myGame.History[0].Drawn)
+		assert.Equal(t, replay.Bag.Tiles, myGame.Bag.Tiles)
+		// Redo after an undo brings the source to its state after the turn
+		assert.NoError(t, myGame.Undo())
+		assert.NoError(t, myGame.Redo())
+		assert.Equal(t, replay.Bag.TakeTiles(RackSize), myGame.Bag.TakeTiles(RackSize))
+	})
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"assets"
+	"errors"
+	"fmt"
+	"path"
+)
+
+// This represents the languages the game can be played in. The dictionary of a language is always played with the
+// tiles of the language.
+
+// DictionaryDirectory is the directory of the dictionary files within the assets
+const DictionaryDirectory = "dicts"
+
+// Language bundles a dictionary with the tile set of its language
+type Language struct {
+	// Code identifies the language, e.g. "en"
+	Code string
+	Name string
+	// DictionaryFile is the name of the DAWG file in the DictionaryDirectory
+	DictionaryFile string
+	TileSet        TileSet
+}
+
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// Languages returns all languages with a dictionary in the DictionaryDirectory
+func Languages() []*Language {
+	return []*Language{
+		{Code: "en", Name: "English", DictionaryFile: "en.dawg", TileSet: EnglishTileSet()},
+		{Code: "fr", Name: "Français", DictionaryFile: "fr.dawg", TileSet: FrenchTileSet()},
+		{Code: "es", Name: "Español", DictionaryFile: "es.dawg", TileSet: SpanishTileSet()},
+		{Code: "de", Name: "Deutsch", DictionaryFile: "de2.dawg", TileSet: GermanTileSet()},
+	}
+}
+
+// LanguageByCode returns the language with the given code
+func LanguageByCode(code string) (*Language, error) {
+	for _, language := range Languages() {
+		if language.Code == code {
+			return language, nil
+		}
+	}
+	return nil, fmt.Errorf("%w '%s'", ErrUnknownLanguage, code)
+}
+
+// LoadDictionary loads the dictionary of the language from the assets
+func (language *Language) LoadDictionary() (*Dictionary, error) {
+	return LoadDictionaryFromFS(assets.FS(), path.Join(DictionaryDirectory, language.DictionaryFile), language.TileSet)
+}
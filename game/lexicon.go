@@ -0,0 +1,123 @@
+package game
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// This represents the word lists moves are validated against. A lexicon is either a single dictionary, a plain list of
+// words, e.g. a house-rules addendum, or the union of several lexicons.
+
+// Lexicon is a list of words identified by its ID
+type Lexicon interface {
+	IsWord(word string) bool
+	// ID identifies the words of the lexicon. Lexicons with the same ID accept the same words.
+	ID() string
+}
+
+var ErrLexiconMismatch = errors.New("lexicon does not match")
+
+// checksumLength is the number of hex digits of a checksum used in a lexicon ID
+const checksumLength = 12
+
+// lexiconID combines the name of a lexicon with the checksum of its words
+func lexiconID(name string, checksum string) string {
+	if len(checksum) > checksumLength {
+		checksum = checksum[:checksumLength]
+	}
+	return fmt.Sprintf("%s@%s", name, checksum)
+}
+
+// WordList is a lexicon of a few words held in memory, e.g. the words allowed by house rules in addition to a dictionary
+type WordList struct {
+	Name  string
+	words map[string]bool
+	id    string
+}
+
+// NewWordList creates a word list with the given name and words
+func NewWordList(name string, words []string) *WordList {
+	list := &WordList{Name: name, words: make(map[string]bool, len(words))}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			list.words[word] = true
+		}
+	}
+	sorted := make([]string, 0, len(list.words))
+	for word := range list.words {
+		sorted = append(sorted, word)
+	}
+	sort.Strings(sorted)
+	hash := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	list.id = lexiconID(name, hex.EncodeToString(hash[:]))
+	return list
+}
+
+// ReadWordList reads a word list with one word per line
+func ReadWordList(name string, reader io.Reader) (*WordList, error) {
+	words := make([]string, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list '%s': %w", name, err)
+	}
+	return NewWordList(name, words), nil
+}
+
+func (list *WordList) IsWord(word string) bool {
+	return list.words[strings.ToLower(word)]
+}
+
+func (list *WordList) ID() string {
+	return list.id
+}
+
+// unionLexicon accepts the words of any of its lexicons
+type unionLexicon []Lexicon
+
+// NewUnionLexicon combines the lexicons into one accepting the words of all of them, e.g. two dictionaries or a
+// dictionary and a house-rules addendum. The order of the lexicons is part of the ID.
+func NewUnionLexicon(lexicons ...Lexicon) Lexicon {
+	if len(lexicons) == 1 {
+		return lexicons[0]
+	}
+	return unionLexicon(lexicons)
+}
+
+func (union unionLexicon) IsWord(word string) bool {
+	for _, lexicon := range union {
+		if lexicon.IsWord(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (union unionLexicon) ID() string {
+	ids := make([]string, len(union))
+	for i, lexicon := range union {
+		ids[i] = lexicon.ID()
+	}
+	return strings.Join(ids, "+")
+}
+
+type LexiconActions interface {
+	// CheckLexicon checks that the ID of a lexicon, e.g. announced by a peer or stored with a saved game, is the ID of
+	// the lexicon the game validates words against
+	CheckLexicon(id string) error
+}
+
+func (game *Game) CheckLexicon(id string) error {
+	if id != game.LexiconID {
+		return fmt.Errorf("%w: expected '%s', got '%s'", ErrLexiconMismatch, game.LexiconID, id)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLexicon(t *testing.T) {
+	t.Run("Dictionary ID depends on its words", func(t *testing.T) {
+		first, err := ReadDictionaryFromCSV(strings.NewReader("cat\ndog\n"), EnglishTileSet())
+		assert.NoError(t, err)
+		first.Name = "pets"
+		second, _ := ReadDictionaryFromCSV(strings.NewReader("dog\ncat\n"), EnglishTileSet())
+		second.Name = "pets"
+		third, _ := ReadDictionaryFromCSV(strings.NewReader("cat\ndog\nemu\n"), EnglishTileSet())
+		third.Name = "pets"
+		assert.Equal(t, first.ID(), second.ID())
+		assert.NotEqual(t, first.ID(), third.ID())
+		assert.Regexp(t, `^pets@[0-9a-f]{12}$`, first.ID())
+	})
+
+	t.Run("Union accepts the words of all lexicons", func(t *testing.T) {
+		base, _ := ReadDictionaryFromCSV(strings.NewReader("cat\n"), EnglishTileSet())
+		base.Name = "base"
+		addendum := NewWordList("house", []string{"Zax", " qi "})
+		union := NewUnionLexicon(base, addendum)
+		assert.True(t, union.IsWord("CAT"))
+		assert.True(t, union.IsWord("zax"))
+		assert.True(t, union.IsWord("QI"))
+		assert.False(t, union.IsWord("dog"))
+		assert.Equal(t, base.ID()+"+"+addendum.ID(), union.ID())
+		assert.Equal(t, base, NewUnionLexicon(base))
+	})
+
+	t.Run("Word lists are read line by line", func(t *testing.T) {
+		list, err := ReadWordList("house", strings.NewReader("qi\nZAX\n\n"))
+		assert.NoError(t, err)
+		assert.True(t, list.IsWord("zax"))
+		assert.Equal(t, NewWordList("house", []string{"zax", "qi"}).ID(), list.ID())
+		assert.NotEqual(t, NewWordList("house", []string{"zax"}).ID(), list.ID())
+	})
+
+	t.Run("Game records the lexicon", func(t *testing.T) {
+		lexicon := NewUnionLexicon(newTestDictionary("cat"), NewWordList("house", []string{"cta"}))
+		myGame, err := NewGame(WithLexicon(lexicon), WithSeed(1))
+		assert.NoError(t, err)
+		assert.Equal(t, lexicon.ID(), myGame.LexiconID)
+		assert.NoError(t, myGame.CheckLexicon(lexicon.ID()))
+		assert.ErrorIs(t, myGame.CheckLexicon(newTestDictionary("cat").ID()), ErrLexiconMismatch)
+
+		myGame.Players = []*Player{NewPlayer("Player 1")}
+		myGame.CurrentPlayer = myGame.Players[0]
+		myGame.Phase = PhaseInProgress
+		assert.True(t, checkMove(myGame, newMove(myGame.Board, "CTA", 6, 7, Horizontal)).IsValid)
+	})
+}
@@ -7,25 +7,68 @@ import (
 	"strings"
 )
 
+// RackSize is the number of tiles on a full rack under the official rules
+const RackSize = 7
+
 type Player struct {
 	Name  string
 	Score int
 	Tiles []Tile
+	// Strategy decides the turns of a computer player, nil for human players, see NewBotPlayer
+	Strategy Strategy `json:"-"`
 }
 
 type PlayerActions interface {
+	// RemoveTile removes the tile with the same ID from the rack
 	RemoveTile(tile Tile)
+	// HasTiles checks if all tiles of the move are on the rack of the player
+	HasTiles(move []Move) bool
+	// HasRackTiles checks if all given tiles are on the rack of the player
+	HasRackTiles(tiles []Tile) bool
+	// IsBot checks if the player is a computer player
+	IsBot() bool
+}
+
+func (player *Player) HasTiles(move []Move) bool {
+	tiles := make([]Tile, len(move))
+	for i, m := range move {
+		tiles[i] = *m.Tile
+	}
+	return player.HasRackTiles(tiles)
+}
+
+func (player *Player) HasRackTiles(tiles []Tile) bool {
+	remaining := make([]Tile, len(player.Tiles))
+	copy(remaining, player.Tiles)
+	for _, tile := range tiles {
+		found := false
+		for i, t := range remaining {
+			if t.ID == tile.ID {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 func (player *Player) RemoveTile(tile Tile) {
 	for i, t := range player.Tiles {
-		if t == tile {
+		if t.ID == tile.ID {
 			player.Tiles = append(player.Tiles[:i], player.Tiles[i+1:]...)
 			return
 		}
 	}
 }
 
+func (player *Player) IsBot() bool {
+	return player.Strategy != nil
+}
+
 func NewPlayerWithRandomName() *Player {
 	flag.Parse()
 	return NewPlayer(strings.ToTitle(fmt.Sprintf("%s %s", petname.Adjective(), petname.Name())))
@@ -1,9 +1,106 @@
 package game
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // This represents rules and checkers for the game of scrabble
 
+// RuleViolation is a reason for rejecting the move of a player
+type RuleViolation int
+
+const (
+	NoTilesPlaced    RuleViolation = iota // The move does not contain any tiles
+	NotInLine                             // The tiles are not in a single row or column
+	NotContiguous                         // There is a gap between the tiles which is not filled by tiles on the board
+	NotConnected                          // None of the tiles touches a tile already on the board
+	MissesCenter                          // The first move of the game does not cover the center star
+	NoWordFormed                          // The tiles do not form a word of at least two letters
+	UnknownWords                          // At least one of the formed words is not in the dictionary
+	TilesNotInRack                        // At least one of the tiles is not on the rack of the player
+	OutOfBounds                           // At least one of the tiles is placed outside the board
+	FieldOccupied                         // At least one of the tiles is placed on a field which is not empty
+	BlankNotAssigned                      // A blank tile is placed without assigning the letter it represents
+	NotYourTurn                           // The game is not in progress or it is not the turn of the player
+	ChallengePending                      // The last move has not been accepted or challenged yet
+)
+
+func (violation RuleViolation) String() string {
+	switch violation {
+	case NoTilesPlaced:
+		return "no tiles placed"
+	case NotInLine:
+		return "tiles are not in a straight line"
+	case NotContiguous:
+		return "gap between two consecutive tiles"
+	case NotConnected:
+		return "tiles are not connected to tiles on the board"
+	case MissesCenter:
+		return "first move does not cover the center star"
+	case NoWordFormed:
+		return "no word formed"
+	case UnknownWords:
+		return "word is not in the dictionary"
+	case TilesNotInRack:
+		return "tiles are not on the rack"
+	case OutOfBounds:
+		return "tiles are outside the board"
+	case FieldOccupied:
+		return "field is already occupied"
+	case BlankNotAssigned:
+		return "no letter assigned to blank tile"
+	case NotYourTurn:
+		return "it is not your turn"
+	case ChallengePending:
+		return "the last move has not been accepted or challenged yet"
+	}
+	return fmt.Sprintf("rule violation %d", int(violation))
+}
+
+// MoveCheckResult is the result of checking a complete move of a player
+type MoveCheckResult struct {
+	IsValid bool
+	// Violations lists every rule violated by the move
+	Violations []RuleViolation
+	// Words lists all words formed by the move, the main word first
+	Words []Word
+	// InvalidWords lists the formed words which are not in the dictionary
+	InvalidWords []Word
+}
+
+// HasViolation checks if the given rule was violated by the move
+func (result MoveCheckResult) HasViolation(violation RuleViolation) bool {
+	for _, v := range result.Violations {
+		if v == violation {
+			return true
+		}
+	}
+	return false
+}
+
+func (result MoveCheckResult) String() string {
+	if result.IsValid {
+		return "valid move"
+	}
+	reasons := make([]string, len(result.Violations))
+	for i, violation := range result.Violations {
+		reasons[i] = violation.String()
+		if violation == UnknownWords {
+			reasons[i] += fmt.Sprintf(" (%s)", strings.Join(wordStrings(result.InvalidWords), ", "))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func wordStrings(words []Word) []string {
+	result := make([]string, len(words))
+	for i, word := range words {
+		result[i] = word.String()
+	}
+	return result
+}
+
 type MoveValidationResult struct {
 	IsValid       bool
 	MovedOnBoard  bool
@@ -14,10 +111,12 @@ type RuleChecker interface {
 	ValidateMove(x int, y int, tile *Tile) MoveValidationResult
 }
 
-func CalculateWordScore(word string) int {
+// CalculateWordScore sums the letter scores of the tiles the word is spelled with, see TileSet.SplitLetters
+func CalculateWordScore(word string, tiles *TileSet) int {
 	score := 0
-	for _, letter := range word {
-		score += LetterScores[strings.ToUpper(string(letter))]
+	letters, _ := tiles.SplitLetters(word)
+	for _, letter := range letters {
+		score += tiles.LetterScores[letter]
 	}
 	return score
 }
@@ -28,7 +127,7 @@ func CalculateWordScore(word string) int {
 // - Tile is already on the board, coordinates are out of bounds => Tile is removed from the board
 // All other moves are invalid
 func (game *Game) ValidateMove(x int, y int, tile *Tile) MoveValidationResult {
-	newCoordinatesWithinBounds := coordinatesWithinBounds(x, y)
+	newCoordinatesWithinBounds := game.Board.withinBounds(x, y)
 	isTileOnBoard := game.Board.IsTileOnBoard(tile)
 	isFieldEmpty := game.Board.IsFieldEmpty(x, y)
 	if !isTileOnBoard && newCoordinatesWithinBounds && isFieldEmpty {
@@ -57,6 +156,74 @@ func (game *Game) ValidateMove(x int, y int, tile *Tile) MoveValidationResult {
 	}
 }
 
-func coordinatesWithinBounds(x int, y int) bool {
-	return !(x < 0 || x > 14 || y < 0 || y > 14)
+// ValidatePlacement checks the placement rules of a move, i.e. all rules which neither depend on the rack of the player
+// nor on the dictionary:
+// - At least one tile is placed, every tile is placed within bounds on an empty field
+// - Every blank tile has a letter assigned
+// - On an empty board, one of the tiles covers the center star, otherwise one of the tiles touches a tile on the board
+// - All tiles are in a straight line without gaps and form at least one word of two letters
+// The words formed by the move are returned along with every violated rule.
+func (r *Board) ValidatePlacement(move []Move) ([]Word, []RuleViolation) {
+	violations := make([]RuleViolation, 0)
+	if len(move) == 0 {
+		return nil, append(violations, NoTilesPlaced)
+	}
+	occupied := make(map[[2]int]bool, len(move))
+	for _, m := range move {
+		if !r.withinBounds(m.X, m.Y) {
+			violations = append(violations, OutOfBounds)
+			return nil, violations
+		}
+		if !r.IsFieldEmpty(m.X, m.Y) || occupied[[2]int{m.X, m.Y}] {
+			violations = append(violations, FieldOccupied)
+			return nil, violations
+		}
+		occupied[[2]int{m.X, m.Y}] = true
+		if m.Tile.IsBlank() && m.Tile.AssignedLetter == "" {
+			violations = append(violations, BlankNotAssigned)
+			return nil, violations
+		}
+	}
+	// The first move has to cover the center star, all following moves have to touch a tile on the board
+	if r.IsEmpty() {
+		if !coversCenter(r, move) {
+			violations = append(violations, MissesCenter)
+		}
+	} else if !touchesBoardTiles(r, move) {
+		violations = append(violations, NotConnected)
+	}
+	if _, ok := r.MoveDirection(move); !ok {
+		return nil, append(violations, NotInLine)
+	}
+	// Gaps between the placed tiles have to be filled by tiles on the board
+	words, ok := r.FormedWords(move)
+	if !ok {
+		return nil, append(violations, NotContiguous)
+	}
+	if len(words) == 0 {
+		violations = append(violations, NoWordFormed)
+	}
+	return words, violations
+}
+
+// coversCenter checks if one of the tiles of the move is placed on the center star
+func coversCenter(board *Board, move []Move) bool {
+	for _, m := range move {
+		if field, ok := board.GetField(m.X, m.Y); ok && field.Type == CS {
+			return true
+		}
+	}
+	return false
+}
+
+// touchesBoardTiles checks if one of the tiles of the move is adjacent to a tile already on the board
+func touchesBoardTiles(board *Board, move []Move) bool {
+	for _, m := range move {
+		for _, neighbour := range [][2]int{{m.X - 1, m.Y}, {m.X + 1, m.Y}, {m.X, m.Y - 1}, {m.X, m.Y + 1}} {
+			if field, ok := board.GetField(neighbour[0], neighbour[1]); ok && field.Tile != nil {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,76 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidatePlacement(t *testing.T) {
+	t.Run("First move covering the center star is valid", func(t *testing.T) {
+		board := NewBoard()
+		words, violations := board.ValidatePlacement(newMove(board, "CAT", 7, 5, Vertical))
+		assert.Empty(t, violations)
+		assert.Equal(t, []string{"CAT"}, wordStrings(words))
+	})
+
+	t.Run("First move off center is rejected", func(t *testing.T) {
+		board := NewBoard()
+		_, violations := board.ValidatePlacement(newMove(board, "CAT", 0, 0, Horizontal))
+		assert.Equal(t, []RuleViolation{MissesCenter}, violations)
+	})
+
+	t.Run("Single tile on an empty board forms no word", func(t *testing.T) {
+		board := NewBoard()
+		_, violations := board.ValidatePlacement(newMove(board, "A", 7, 7, Horizontal))
+		assert.Equal(t, []RuleViolation{NoWordFormed}, violations)
+	})
+
+	t.Run("Move hooking onto a word is connected", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 6, 7, Horizontal)
+		words, violations := board.ValidatePlacement(newMove(board, "S", 9, 7, Horizontal))
+		assert.Empty(t, violations)
+		assert.Equal(t, []string{"CATS"}, wordStrings(words))
+	})
+
+	t.Run("Move through tiles on the board is connected", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 6, 7, Horizontal)
+		words, violations := board.ValidatePlacement(newMove(board, "BT", 7, 6, Vertical))
+		assert.Empty(t, violations)
+		assert.Equal(t, []string{"BAT"}, wordStrings(words))
+	})
+
+	t.Run("Island away from the tiles on the board is rejected", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 6, 7, Horizontal)
+		_, violations := board.ValidatePlacement(newMove(board, "DOG", 0, 0, Horizontal))
+		assert.Equal(t, []RuleViolation{NotConnected}, violations)
+	})
+
+	t.Run("Island touching the center on a non empty board is rejected", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 0, 0, Horizontal)
+		_, violations := board.ValidatePlacement(newMove(board, "DOG", 7, 7, Horizontal))
+		assert.Equal(t, []RuleViolation{NotConnected}, violations)
+	})
+
+	t.Run("Tile on an occupied field is rejected", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 6, 7, Horizontal)
+		_, violations := board.ValidatePlacement([]Move{{X: 7, Y: 7, Tile: newTestTile("O", 1)}})
+		assert.Equal(t, []RuleViolation{FieldOccupied}, violations)
+	})
+
+	t.Run("Tile outside the board is rejected", func(t *testing.T) {
+		board := NewBoard()
+		_, violations := board.ValidatePlacement(newMove(board, "CAT", 13, 7, Horizontal))
+		assert.Equal(t, []RuleViolation{OutOfBounds}, violations)
+	})
+
+	t.Run("Empty move is rejected", func(t *testing.T) {
+		board := NewBoard()
+		_, violations := board.ValidatePlacement([]Move{})
+		assert.Equal(t, []RuleViolation{NoTilesPlaced}, violations)
+	})
+}
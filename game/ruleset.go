@@ -0,0 +1,229 @@
+package game
+
+import (
+	"config"
+	"fmt"
+)
+
+// This represents the rule variants the game can be played with
+
+// RuleSet bundles all rules which differ between the variants of the game
+type RuleSet struct {
+	Name string
+	// SpecialFields is the premium layout of the board indexed by x and y. Its dimensions determine the board size.
+	SpecialFields [][]int
+	RackSize      int
+	// BingoBonus is awarded for placing all tiles of a full rack in a single move
+	BingoBonus int
+	// TileSet holds the letter scores and the tile distribution
+	TileSet
+	// ExchangeMinimum is the minimum number of tiles in the bag to allow exchanging tiles
+	ExchangeMinimum int
+	// MaxScorelessTurns is the number of consecutive scoreless turns which end the game
+	MaxScorelessTurns int
+	// CenterStarDoublesWord is true if the center star counts as a double word field
+	CenterStarDoublesWord bool
+	// LeftoversToPlayerOut is true if the player who went out gets the values of the tiles left on all other racks
+	LeftoversToPlayerOut bool
+	// Challenge decides how words which are not in the dictionary are handled
+	Challenge ChallengeRule
+	// ChallengePenalty is subtracted from the score of a player who challenges a valid move under the single challenge
+	// rule
+	ChallengePenalty int
+}
+
+// Size returns the number of columns and rows of the board
+func (rules *RuleSet) Size() (int, int) {
+	if len(rules.SpecialFields) == 0 {
+		return 0, 0
+	}
+	return len(rules.SpecialFields), len(rules.SpecialFields[0])
+}
+
+// OfficialRules returns the rules of the official game on a 15x15 board
+func OfficialRules() *RuleSet {
+	return &RuleSet{
+		Name:                  "Scrabble",
+		SpecialFields:         specialFields,
+		RackSize:              RackSize,
+		BingoBonus:            BingoBonus,
+		TileSet:               EnglishTileSet(),
+		ExchangeMinimum:       ExchangeMinimum,
+		MaxScorelessTurns:     MaxScorelessTurns,
+		CenterStarDoublesWord: true,
+		LeftoversToPlayerOut:  true,
+	}
+}
+
+// RuleSetFromConfig returns the official rules with the board layout, letter scores and tile distribution of the
+// config. An invalid config is rejected with the problems found.
+func RuleSetFromConfig(cfg *config.Config) (*RuleSet, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	rules := OfficialRules()
+	rules.SpecialFields = cfg.SpecialFields
+	rules.LetterScores = cfg.LetterScores
+	rules.TileDistribution = cfg.TileDist
+	return rules, nil
+}
+
+// WordsWithFriendsRules returns the rules of the Words with Friends variant with its own board layout, letter values
+// and tile distribution. The center star is not a premium field.
+func WordsWithFriendsRules() *RuleSet {
+	return &RuleSet{
+		Name: "Words with Friends",
+		SpecialFields: [][]int{
+			{NF, NF, NF, TW, NF, NF, TL, NF, TL, NF, NF, TW, NF, NF, NF},
+			{NF, NF, DL, NF, NF, DW, NF, NF, NF, DW, NF, NF, DL, NF, NF},
+			{NF, DL, NF, NF, DL, NF, NF, NF, NF, NF, DL, NF, NF, DL, NF},
+			{TW, NF, NF, TL, NF, NF, NF, DW, NF, NF, NF, TL, NF, NF, TW},
+			{NF, NF, DL, NF, NF, NF, DL, NF, DL, NF, NF, NF, DL, NF, NF},
+			{NF, DW, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, DW, NF},
+			{TL, NF, NF, NF, DL, NF, NF, NF, NF, NF, DL, NF, NF, NF, TL},
+			{NF, NF, NF, DW, NF, NF, NF, CS, NF, NF, NF, DW, NF, NF, NF},
+			{TL, NF, NF, NF, DL, NF, NF, NF, NF, NF, DL, NF, NF, NF, TL},
+			{NF, DW, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, DW, NF},
+			{NF, NF, DL, NF, NF, NF, DL, NF, DL, NF, NF, NF, DL, NF, NF},
+			{TW, NF, NF, TL, NF, NF, NF, DW, NF, NF, NF, TL, NF, NF, TW},
+			{NF, DL, NF, NF, DL, NF, NF, NF, NF, NF, DL, NF, NF, DL, NF},
+			{NF, NF, DL, NF, NF, DW, NF, NF, NF, DW, NF, NF, DL, NF, NF},
+			{NF, NF, NF, TW, NF, NF, TL, NF, TL, NF, NF, TW, NF, NF, NF},
+		},
+		RackSize:   7,
+		BingoBonus: 35,
+		TileSet: TileSet{
+			LetterScores: map[string]int{
+				"A": 1,
+				"B": 4,
+				"C": 4,
+				"D": 2,
+				"E": 1,
+				"F": 4,
+				"G": 3,
+				"H": 3,
+				"I": 1,
+				"J": 10,
+				"K": 5,
+				"L": 2,
+				"M": 4,
+				"N": 2,
+				"O": 1,
+				"P": 4,
+				"Q": 10,
+				"R": 1,
+				"S": 1,
+				"T": 1,
+				"U": 2,
+				"V": 5,
+				"W": 4,
+				"X": 8,
+				"Y": 3,
+				"Z": 10,
+				"*": 0,
+			},
+			TileDistribution: map[string]int{
+				"A": 9,
+				"B": 2,
+				"C": 2,
+				"D": 5,
+				"E": 13,
+				"F": 2,
+				"G": 3,
+				"H": 4,
+				"I": 8,
+				"J": 1,
+				"K": 1,
+				"L": 4,
+				"M": 2,
+				"N": 5,
+				"O": 8,
+				"P": 2,
+				"Q": 1,
+				"R": 6,
+				"S": 5,
+				"T": 7,
+				"U": 4,
+				"V": 2,
+				"W": 2,
+				"X": 1,
+				"Y": 2,
+				"Z": 1,
+				"*": 2,
+			},
+		},
+		ExchangeMinimum:       7,
+		MaxScorelessTurns:     6,
+		CenterStarDoublesWord: false,
+		LeftoversToPlayerOut:  true,
+	}
+}
+
+// SuperScrabbleRules returns the rules of Super Scrabble, played on a 21x21 board with quadruple letter and word fields
+// and a bag of 200 tiles
+func SuperScrabbleRules() *RuleSet {
+	return &RuleSet{
+		Name: "Super Scrabble",
+		SpecialFields: [][]int{
+			{QW, NF, NF, DL, NF, NF, NF, TW, NF, NF, DL, NF, NF, TW, NF, NF, NF, DL, NF, NF, QW},
+			{NF, DW, NF, NF, TL, NF, NF, NF, DW, NF, NF, NF, DW, NF, NF, NF, TL, NF, NF, DW, NF},
+			{NF, NF, DW, NF, NF, QL, NF, NF, NF, DW, NF, DW, NF, NF, NF, QL, NF, NF, DW, NF, NF},
+			{DL, NF, NF, DW, NF, NF, DL, NF, NF, NF, TW, NF, NF, NF, DL, NF, NF, DW, NF, NF, DL},
+			{NF, TL, NF, NF, DW, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, DW, NF, NF, TL, NF},
+			{NF, NF, QL, NF, NF, DW, NF, NF, NF, DL, NF, DL, NF, NF, NF, DW, NF, NF, QL, NF, NF},
+			{NF, NF, NF, DL, NF, NF, DW, NF, NF, NF, DL, NF, NF, NF, DW, NF, NF, DL, NF, NF, NF},
+			{TW, NF, NF, NF, NF, NF, NF, DW, NF, NF, NF, NF, NF, DW, NF, NF, NF, NF, NF, NF, TW},
+			{NF, DW, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, DW, NF},
+			{NF, NF, DW, NF, NF, DL, NF, NF, NF, DL, NF, DL, NF, NF, NF, DL, NF, NF, DW, NF, NF},
+			{DL, NF, NF, TW, NF, NF, DL, NF, NF, NF, CS, NF, NF, NF, DL, NF, NF, TW, NF, NF, DL},
+			{NF, NF, DW, NF, NF, DL, NF, NF, NF, DL, NF, DL, NF, NF, NF, DL, NF, NF, DW, NF, NF},
+			{NF, DW, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, DW, NF},
+			{TW, NF, NF, NF, NF, NF, NF, DW, NF, NF, NF, NF, NF, DW, NF, NF, NF, NF, NF, NF, TW},
+			{NF, NF, NF, DL, NF, NF, DW, NF, NF, NF, DL, NF, NF, NF, DW, NF, NF, DL, NF, NF, NF},
+			{NF, NF, QL, NF, NF, DW, NF, NF, NF, DL, NF, DL, NF, NF, NF, DW, NF, NF, QL, NF, NF},
+			{NF, TL, NF, NF, DW, NF, NF, NF, TL, NF, NF, NF, TL, NF, NF, NF, DW, NF, NF, TL, NF},
+			{DL, NF, NF, DW, NF, NF, DL, NF, NF, NF, TW, NF, NF, NF, DL, NF, NF, DW, NF, NF, DL},
+			{NF, NF, DW, NF, NF, QL, NF, NF, NF, DW, NF, DW, NF, NF, NF, QL, NF, NF, DW, NF, NF},
+			{NF, DW, NF, NF, TL, NF, NF, NF, DW, NF, NF, NF, DW, NF, NF, NF, TL, NF, NF, DW, NF},
+			{QW, NF, NF, DL, NF, NF, NF, TW, NF, NF, DL, NF, NF, TW, NF, NF, NF, DL, NF, NF, QW},
+		},
+		RackSize:   7,
+		BingoBonus: 50,
+		TileSet: TileSet{
+			LetterScores: LetterScores,
+			TileDistribution: map[string]int{
+				"A": 16,
+				"B": 4,
+				"C": 6,
+				"D": 8,
+				"E": 24,
+				"F": 4,
+				"G": 5,
+				"H": 5,
+				"I": 13,
+				"J": 2,
+				"K": 2,
+				"L": 7,
+				"M": 6,
+				"N": 13,
+				"O": 15,
+				"P": 4,
+				"Q": 2,
+				"R": 13,
+				"S": 10,
+				"T": 15,
+				"U": 7,
+				"V": 3,
+				"W": 4,
+				"X": 2,
+				"Y": 4,
+				"Z": 2,
+				"*": 4,
+			},
+		},
+		ExchangeMinimum:       7,
+		MaxScorelessTurns:     6,
+		CenterStarDoublesWord: true,
+		LeftoversToPlayerOut:  true,
+	}
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"config"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestRuleSets(t *testing.T) {
+	t.Run("Rule sets define symmetric boards and full bags", func(t *testing.T) {
+		for _, test := range []struct {
+			rules *RuleSet
+			size  int
+			tiles int
+		}{
+			{OfficialRules(), 15, 100},
+			{WordsWithFriendsRules(), 15, 104},
+			{SuperScrabbleRules(), 21, 200},
+		} {
+			board := NewBoardWithRuleSet(test.rules)
+			assert.Len(t, board.Fields, test.size, test.rules.Name)
+			center := test.size / 2
+			assert.Equal(t, CS, board.Fields[center][center].Type, test.rules.Name)
+			for x := 0; x < test.size; x++ {
+				for y := 0; y < test.size; y++ {
+					assert.Equal(t, board.Fields[x][y].Type, board.Fields[y][x].Type, test.rules.Name)
+					assert.Equal(t, board.Fields[x][y].Type, board.Fields[test.size-1-x][y].Type, test.rules.Name)
+				}
+			}
+			_, ok := board.GetField(test.size-1, test.size-1)
+			assert.True(t, ok, test.rules.Name)
+			_, ok = board.GetField(test.size, 0)
+			assert.False(t, ok, test.rules.Name)
+			assert.Len(t, NewBagWithRuleSet(test.rules, rand.NewSource(1)).Tiles, test.tiles, test.rules.Name)
+		}
+	})
+
+	t.Run("Super Scrabble scores quadruple fields", func(t *testing.T) {
+		board := NewBoardWithRuleSet(SuperScrabbleRules())
+		// The corner is a quadruple word field
+		score := board.ScoreWord(Word{Tiles: []WordTile{
+			{Move: Move{X: 0, Y: 0, Tile: newTestTile("A", 1)}, Placed: true},
+			{Move: Move{X: 1, Y: 0, Tile: newTestTile("T", 1)}, Placed: true},
+		}})
+		assert.Equal(t, 8, score)
+		// "Q" on a quadruple letter field
+		score = board.ScoreWord(Word{Tiles: []WordTile{
+			{Move: Move{X: 2, Y: 5, Tile: newTestTile("Q", 10)}, Placed: true},
+			{Move: Move{X: 3, Y: 5, Tile: newTestTile("I", 1)}, Placed: true},
+		}})
+		assert.Equal(t, 41, score)
+	})
+
+	t.Run("Words with Friends does not double the first word", func(t *testing.T) {
+		board := NewBoardWithRuleSet(WordsWithFriendsRules())
+		score := board.ScoreMove(newMove(board, "CAT", 6, 7, Horizontal))
+		assert.Equal(t, 5, score.Total)
+		score = board.ScoreMove(newMove(board, "AAAAAAA", 4, 7, Horizontal))
+		assert.Equal(t, 35, score.Bingo)
+	})
+
+	t.Run("Game uses the rack size and bag of its rules", func(t *testing.T) {
+		rules := SuperScrabbleRules()
+		rules.RackSize = 8
+		myGame := newTestLobby("Player 1", "Player 2")
+		myGame.Rules = rules
+		myGame.Board = NewBoardWithRuleSet(rules)
+		myGame.Bag = NewBagWithRuleSet(rules, rand.NewSource(1))
+		assert.NoError(t, myGame.Start())
+		for _, player := range myGame.Players {
+			assert.Len(t, player.Tiles, 8)
+		}
+		assert.Len(t, myGame.Bag.Tiles, 200-16)
+	})
+
+	t.Run("Rule set from config", func(t *testing.T) {
+		cfg := config.NewConfig()
+		cfg.SpecialFields = [][]int{{TW, NF, TW}, {NF, CS, NF}, {TW, NF, TW}}
+		cfg.TileDist = map[string]int{"A": 4}
+		rules, err := RuleSetFromConfig(cfg)
+		assert.NoError(t, err)
+		columns, rows := rules.Size()
+		assert.Equal(t, 3, columns)
+		assert.Equal(t, 3, rows)
+		assert.Equal(t, RackSize, rules.RackSize)
+		assert.Len(t, NewBagWithRuleSet(rules, rand.NewSource(1)).Tiles, 4)
+
+		cfg.SpecialFields = [][]int{{TW, NF}, {NF}}
+		_, err = RuleSetFromConfig(cfg)
+		assert.ErrorIs(t, err, config.ErrInvalidDimensions)
+		assert.ErrorIs(t, err, config.ErrNoCenterStar)
+	})
+}
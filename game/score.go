@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This represents the scoring of moves in the game of scrabble
+
+// BingoBonus is awarded for placing all tiles of a full rack in a single move under the official rules
+const BingoBonus = 50
+
+// WordScore is the score of a single word formed by a move
+type WordScore struct {
+	Word  Word
+	Score int
+}
+
+// MoveScore is the score of a move broken down into the scores of all words formed by the move
+type MoveScore struct {
+	Words []WordScore
+	// Bingo is the bonus for placing all tiles of the rack, zero otherwise
+	Bingo int
+	Total int
+}
+
+func (score MoveScore) String() string {
+	parts := make([]string, 0, len(score.Words)+1)
+	for _, wordScore := range score.Words {
+		parts = append(parts, fmt.Sprintf("%s %d", wordScore.Word, wordScore.Score))
+	}
+	if score.Bingo > 0 {
+		parts = append(parts, fmt.Sprintf("Bingo %d", score.Bingo))
+	}
+	return fmt.Sprintf("%s = %d", strings.Join(parts, " + "), score.Total)
+}
+
+// ScoreWord calculates the score of a word. Premium fields only count for tiles placed by the move which formed the
+// word, tiles already on the board only count with their letter score. The center star doubles the word score unless
+// the rules of the board say otherwise.
+func (r *Board) ScoreWord(word Word) int {
+	score := 0
+	wordMultiplier := 1
+	for _, tile := range word.Tiles {
+		letterScore := tile.Tile.LetterScore
+		if field, ok := r.GetField(tile.X, tile.Y); ok && tile.Placed {
+			switch field.Type {
+			case DL:
+				letterScore *= 2
+			case TL:
+				letterScore *= 3
+			case QL:
+				letterScore *= 4
+			case DW:
+				wordMultiplier *= 2
+			case CS:
+				if r.Rules.CenterStarDoublesWord {
+					wordMultiplier *= 2
+				}
+			case TW:
+				wordMultiplier *= 3
+			case QW:
+				wordMultiplier *= 4
+			}
+		}
+		score += letterScore
+	}
+	return score * wordMultiplier
+}
+
+// ScoreMove calculates the score of a move before its tiles are placed on the board. Every word formed by the move is
+// scored and the bingo bonus is added if all tiles of a full rack are placed. A move which does not form contiguous
+// words scores nothing.
+func (r *Board) ScoreMove(move []Move) MoveScore {
+	score := MoveScore{Words: make([]WordScore, 0)}
+	words, ok := r.FormedWords(move)
+	if !ok {
+		return score
+	}
+	for _, word := range words {
+		wordScore := WordScore{Word: word, Score: r.ScoreWord(word)}
+		score.Words = append(score.Words, wordScore)
+		score.Total += wordScore.Score
+	}
+	if len(move) == r.Rules.RackSize {
+		score.Bingo = r.Rules.BingoBonus
+		score.Total += score.Bingo
+	}
+	return score
+}
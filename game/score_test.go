@@ -0,0 +1,47 @@
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreMove(t *testing.T) {
+	t.Run("First move doubles on the center star", func(t *testing.T) {
+		board := NewBoard()
+		score := board.ScoreMove(newMove(board, "CAT", 6, 7, Horizontal))
+		assert.Equal(t, 10, score.Total)
+		assert.Equal(t, 0, score.Bingo)
+		assert.Equal(t, "CAT 10 = 10", score.String())
+	})
+
+	t.Run("Premium fields count only for placed tiles", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 6, 7, Horizontal)
+		score := board.ScoreMove(newMove(board, "S", 9, 7, Horizontal))
+		assert.Equal(t, 6, score.Total)
+	})
+
+	t.Run("Every formed word is scored", func(t *testing.T) {
+		board := NewBoard()
+		placeWord(board, "CAT", 6, 7, Horizontal)
+		// "A" on a double letter field below "C", "T" below "A"
+		score := board.ScoreMove(newMove(board, "AT", 6, 8, Horizontal))
+		assert.Len(t, score.Words, 3)
+		assert.Equal(t, WordScore{Word: score.Words[0].Word, Score: 3}, score.Words[0])
+		assert.Equal(t, "AT 3 + CA 5 + AT 2 = 10", score.String())
+	})
+
+	t.Run("Bingo bonus for placing all tiles", func(t *testing.T) {
+		board := NewBoard()
+		score := board.ScoreMove(newMove(board, "AAAAAAA", 4, 7, Horizontal))
+		assert.Equal(t, BingoBonus, score.Bingo)
+		assert.Equal(t, 7*2+BingoBonus, score.Total)
+	})
+
+	t.Run("Invalid placement scores nothing", func(t *testing.T) {
+		board := NewBoard()
+		score := board.ScoreMove([]Move{{X: 0, Y: 0, Tile: newTestTile("A", 1)}, {X: 2, Y: 0, Tile: newTestTile("A", 1)}})
+		assert.Equal(t, 0, score.Total)
+		assert.Empty(t, score.Words)
+	})
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"sort"
+	"strings"
+)
+
+// This represents the end of a game and the final standings of the players
+
+// MaxScorelessTurns is the number of consecutive scoreless turns under the official rules, i.e. passes, exchanges and
+// plays without points, after which the game ends
+const MaxScorelessTurns = 6
+
+// EndReason tells why a game ended
+type EndReason int
+
+const (
+	NotEnded         EndReason = iota // The game is not over yet
+	PlayerWentOut                     // A player played all tiles while the bag was empty
+	TooManyScoreless                  // Too many consecutive turns without points
+)
+
+func (reason EndReason) String() string {
+	switch reason {
+	case NotEnded:
+		return "not ended"
+	case PlayerWentOut:
+		return "player went out"
+	case TooManyScoreless:
+		return "too many scoreless turns"
+	}
+	return fmt.Sprintf("end reason %d", int(reason))
+}
+
+// Standing is the position of a single player in the standings
+type Standing struct {
+	Player *Player
+	// Rank starting at 1, players with equal scores share the same rank
+	Rank  int
+	Score int
+	// Adjustment is the end game adjustment already included in the score: The value of the tiles left on the rack is
+	// subtracted, the player who went out receives the value of the tiles left on all other racks.
+	Adjustment     int
+	RemainingTiles []Tile
+}
+
+// Standings ranks all players of a game by their score
+type Standings struct {
+	Players []Standing
+	Reason  EndReason
+	// WentOut is the player who played all tiles, nil if the game did not end this way
+	WentOut *Player
+}
+
+func (standings Standings) String() string {
+	lines := make([]string, len(standings.Players))
+	for i, standing := range standings.Players {
+		lines[i] = fmt.Sprintf("%d. %s %d", standing.Rank, standing.Player.Name, standing.Score)
+		if standing.Adjustment != 0 {
+			lines[i] += fmt.Sprintf(" (%+d)", standing.Adjustment)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Winners returns all players sharing the first rank
+func (standings Standings) Winners() []*Player {
+	winners := make([]*Player, 0)
+	for _, standing := range standings.Players {
+		if standing.Rank == 1 {
+			winners = append(winners, standing.Player)
+		}
+	}
+	return winners
+}
+
+type StandingsActions interface {
+	// Standings ranks the players by their current score
+	Standings() Standings
+}
+
+func (game *Game) Standings() Standings {
+	if game.Result != nil {
+		return *game.Result
+	}
+	return rankPlayers(game.Players, map[*Player]int{})
+}
+
+// endTurn completes the turn of the current player who scored the given points. The game ends once a player has
+// played all tiles while the bag is empty or after too many consecutive scoreless turns. Otherwise, it is the next
+// player's turn.
+func (game *Game) endTurn(score int) {
+	if score > 0 {
+		game.ScorelessTurns = 0
+	} else {
+		game.ScorelessTurns++
+	}
+	if len(game.Bag.Tiles) == 0 && len(game.CurrentPlayer.Tiles) == 0 {
+		game.end(PlayerWentOut)
+		return
+	}
+	if game.ScorelessTurns >= game.Rules.MaxScorelessTurns {
+		game.end(TooManyScoreless)
+		return
+	}
+	game.nextTurn()
+}
+
+// end finishes the game and applies the end game adjustments to the scores of the players
+func (game *Game) end(reason EndReason) {
+	adjustments := make(map[*Player]int, len(game.Players))
+	leftovers := 0
+	for _, player := range game.Players {
+		value := 0
+		for _, tile := range player.Tiles {
+			value += tile.LetterScore
+		}
+		adjustments[player] = -value
+		leftovers += value
+	}
+	var wentOut *Player
+	if reason == PlayerWentOut && game.Rules.LeftoversToPlayerOut {
+		wentOut = game.CurrentPlayer
+		adjustments[wentOut] = leftovers
+	}
+	for _, player := range game.Players {
+		player.Score += adjustments[player]
+		game.publish(ScoreChanged{Player: player, Score: player.Score})
+	}
+	standings := rankPlayers(game.Players, adjustments)
+	standings.Reason = reason
+	standings.WentOut = wentOut
+	game.Result = &standings
+	game.Phase = PhaseFinished
+	zap.L().Debug(fmt.Sprintf("Game ended (%s):\n%s", reason, standings))
+	game.publish(GameEnded{Standings: standings})
+}
+
+func rankPlayers(players []*Player, adjustments map[*Player]int) Standings {
+	standings := Standings{Players: make([]Standing, len(players))}
+	for i, player := range players {
+		standings.Players[i] = Standing{
+			Player:         player,
+			Score:          player.Score,
+			Adjustment:     adjustments[player],
+			RemainingTiles: append([]Tile{}, player.Tiles...),
+		}
+	}
+	sort.SliceStable(standings.Players, func(i, j int) bool {
+		return standings.Players[i].Score > standings.Players[j].Score
+	})
+	for i := range standings.Players {
+		if i > 0 && standings.Players[i].Score == standings.Players[i-1].Score {
+			standings.Players[i].Rank = standings.Players[i-1].Rank
+		} else {
+			standings.Players[i].Rank = i + 1
+		}
+	}
+	return standings
+}
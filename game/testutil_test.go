@@ -0,0 +1,71 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// bruteForceGenerator generates moves by trying every placement of the rack tiles in a row or column near the tiles on
+// the board. It is only fast enough for a few tiles.
+type bruteForceGenerator struct {
+	lexicon Lexicon
+}
+
+func (generator bruteForceGenerator) GenerateMoves(board *Board, rack []Tile) []Placement {
+	placements := make([]Placement, 0)
+	seen := make(map[string]bool)
+	var place func(move []Move, used []bool, x int, y int, dx int, dy int)
+	place = func(move []Move, used []bool, x int, y int, dx int, dy int) {
+		for !board.IsFieldEmpty(x, y) {
+			if _, ok := board.GetField(x, y); !ok {
+				return
+			}
+			x, y = x+dx, y+dy
+		}
+		for i := range rack {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			tile := rack[i]
+			next := append(append([]Move{}, move...), Move{X: x, Y: y, Tile: &tile})
+			words, violations := board.ValidatePlacement(next)
+			valid := len(violations) == 0
+			for _, word := range words {
+				valid = valid && generator.lexicon.IsWord(word.String())
+			}
+			key := fmt.Sprint(next[0].X, next[0].Y, len(next), wordStrings(words))
+			if valid && !seen[key] {
+				seen[key] = true
+				placements = append(placements, Placement{Move: next, Score: board.ScoreMove(next)})
+			}
+			place(next, used, x+dx, y+dy, dx, dy)
+			used[i] = false
+		}
+	}
+	for x := range board.Fields {
+		for y := range board.Fields[x] {
+			if !nearTiles(board, x, y, len(rack)) {
+				continue
+			}
+			place(nil, make([]bool, len(rack)), x, y, 1, 0)
+			place(nil, make([]bool, len(rack)), x, y, 0, 1)
+		}
+	}
+	sort.SliceStable(placements, func(a, b int) bool {
+		return placements[a].Score.Total > placements[b].Score.Total
+	})
+	return placements
+}
+
+// nearTiles checks if a tile is on the board within the distance of the field
+func nearTiles(board *Board, x int, y int, distance int) bool {
+	for i := x - distance; i <= x+distance; i++ {
+		for j := y - distance; j <= y+distance; j++ {
+			if field, ok := board.GetField(i, j); ok && field.Tile != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
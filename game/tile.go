@@ -1,12 +1,66 @@
 package game
 
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// BlankLetter is the letter of blank tiles, which can represent any letter
+const BlankLetter = "*"
+
 type Tile struct {
+	// ID identifies the tile within its bag, so tiles with the same letter can be told apart. Tiles which do not come
+	// from a bag have a negative ID, which is unique within the process, see NewTile.
+	ID          int
 	Letter      string
 	LetterScore int
+	// AssignedLetter is the letter a blank tile represents once it is placed, empty for regular tiles
+	AssignedLetter string
+}
+
+type TileActions interface {
+	IsBlank() bool
+	// PlayedLetter returns the letter the tile represents on the board, which is the assigned letter for blank tiles
+	PlayedLetter() string
+	// AssignLetter assigns the letter a blank tile represents. It fails for regular tiles and letters which are not in
+	// the tile set.
+	AssignLetter(letter string, tiles *TileSet) bool
+	// ResetAssignment removes the assigned letter of a blank tile, e.g. when it is taken back to the rack
+	ResetAssignment()
 }
 
+func (tile *Tile) IsBlank() bool {
+	return tile.Letter == BlankLetter
+}
+
+func (tile *Tile) PlayedLetter() string {
+	if tile.IsBlank() && tile.AssignedLetter != "" {
+		return tile.AssignedLetter
+	}
+	return tile.Letter
+}
+
+func (tile *Tile) AssignLetter(letter string, tiles *TileSet) bool {
+	letter = strings.ToUpper(letter)
+	if !tile.IsBlank() || !tiles.IsLetter(letter) {
+		return false
+	}
+	tile.AssignedLetter = letter
+	return true
+}
+
+func (tile *Tile) ResetAssignment() {
+	tile.AssignedLetter = ""
+}
+
+// lastTileID is the ID of the last tile created by NewTile
+var lastTileID int64
+
+// NewTile creates a tile with a new negative ID, so it can be told apart from every other tile, including the tiles of a
+// bag, which are numbered from one
 func NewTile(letter string, letterScore int) *Tile {
 	tile := &Tile{
+		ID:          int(atomic.AddInt64(&lastTileID, -1)),
 		Letter:      letter,
 		LetterScore: letterScore,
 	}
@@ -0,0 +1,294 @@
+package game
+
+import (
+	"sort"
+	"strings"
+)
+
+// This represents the tiles of a language, i.e. which letters are in the bag, how often and how many points they score.
+// A tile may show several letters, e.g. the Spanish "CH".
+
+// TileSet defines the letters, their scores and their number of tiles in the bag
+type TileSet struct {
+	LetterScores map[string]int
+	// TileDistribution is the number of tiles per letter in the bag
+	TileDistribution map[string]int
+}
+
+type TileSetActions interface {
+	// IsLetter checks if the letter is on a tile of the set, the blank letter excluded
+	IsLetter(letter string) bool
+	// Letters returns the letters of the set in alphabetical order, the blank letter excluded
+	Letters() []string
+	// SplitLetters splits the word into the letters of the tiles it is spelled with. Letters of several runes take
+	// precedence, e.g. the Spanish "CHE" is split into "CH" and "E". The second return value is false if the word
+	// contains a rune which is not on any tile of the set.
+	SplitLetters(word string) ([]string, bool)
+	// SpellsWord checks if the tiles of the word are the tiles it is spelled with, e.g. a Spanish "CH" has to be played
+	// with the CH tile instead of the tiles C and H
+	SpellsWord(word Word) bool
+}
+
+func (tiles *TileSet) IsLetter(letter string) bool {
+	_, ok := tiles.LetterScores[letter]
+	return ok && letter != BlankLetter
+}
+
+func (tiles *TileSet) Letters() []string {
+	letters := make([]string, 0, len(tiles.LetterScores))
+	for letter := range tiles.LetterScores {
+		if letter != BlankLetter {
+			letters = append(letters, letter)
+		}
+	}
+	sort.Strings(letters)
+	return letters
+}
+
+func (tiles *TileSet) SplitLetters(word string) ([]string, bool) {
+	// Try the longest letters first
+	letters := tiles.Letters()
+	sort.SliceStable(letters, func(i, j int) bool {
+		return len([]rune(letters[i])) > len([]rune(letters[j]))
+	})
+	word = strings.ToUpper(word)
+	split := make([]string, 0, len(word))
+	for len(word) > 0 {
+		found := false
+		for _, letter := range letters {
+			if strings.HasPrefix(word, letter) {
+				split = append(split, letter)
+				word = word[len(letter):]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return split, false
+		}
+	}
+	return split, true
+}
+
+func (tiles *TileSet) SpellsWord(word Word) bool {
+	letters, ok := tiles.SplitLetters(word.String())
+	if !ok || len(letters) != len(word.Tiles) {
+		return false
+	}
+	for i, tile := range word.Tiles {
+		if tile.Tile.PlayedLetter() != letters[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EnglishTileSet returns the tiles of the official English game
+func EnglishTileSet() TileSet {
+	return TileSet{
+		LetterScores:     LetterScores,
+		TileDistribution: tileDistribution,
+	}
+}
+
+// FrenchTileSet returns the tiles of the official French game. Accents are not on the tiles.
+func FrenchTileSet() TileSet {
+	return TileSet{
+		LetterScores: map[string]int{
+			"A": 1,
+			"B": 3,
+			"C": 3,
+			"D": 2,
+			"E": 1,
+			"F": 4,
+			"G": 2,
+			"H": 4,
+			"I": 1,
+			"J": 8,
+			"K": 10,
+			"L": 1,
+			"M": 2,
+			"N": 1,
+			"O": 1,
+			"P": 3,
+			"Q": 8,
+			"R": 1,
+			"S": 1,
+			"T": 1,
+			"U": 1,
+			"V": 4,
+			"W": 10,
+			"X": 10,
+			"Y": 10,
+			"Z": 10,
+			"*": 0,
+		},
+		TileDistribution: map[string]int{
+			"A": 9,
+			"B": 2,
+			"C": 2,
+			"D": 3,
+			"E": 15,
+			"F": 2,
+			"G": 2,
+			"H": 2,
+			"I": 8,
+			"J": 1,
+			"K": 1,
+			"L": 5,
+			"M": 3,
+			"N": 6,
+			"O": 6,
+			"P": 2,
+			"Q": 1,
+			"R": 6,
+			"S": 6,
+			"T": 6,
+			"U": 6,
+			"V": 2,
+			"W": 1,
+			"X": 1,
+			"Y": 1,
+			"Z": 1,
+			"*": 2,
+		},
+	}
+}
+
+// SpanishTileSet returns the tiles of the official Spanish game with the tiles CH, LL, RR and Ñ
+func SpanishTileSet() TileSet {
+	return TileSet{
+		LetterScores: map[string]int{
+			"A":  1,
+			"B":  3,
+			"C":  3,
+			"CH": 5,
+			"D":  2,
+			"E":  1,
+			"F":  4,
+			"G":  2,
+			"H":  4,
+			"I":  1,
+			"J":  8,
+			"L":  1,
+			"LL": 8,
+			"M":  3,
+			"N":  1,
+			"Ñ":  8,
+			"O":  1,
+			"P":  3,
+			"Q":  5,
+			"R":  1,
+			"RR": 8,
+			"S":  1,
+			"T":  1,
+			"U":  1,
+			"V":  4,
+			"X":  8,
+			"Y":  4,
+			"Z":  10,
+			"*":  0,
+		},
+		TileDistribution: map[string]int{
+			"A":  12,
+			"B":  2,
+			"C":  4,
+			"CH": 1,
+			"D":  5,
+			"E":  12,
+			"F":  1,
+			"G":  2,
+			"H":  2,
+			"I":  6,
+			"J":  1,
+			"L":  4,
+			"LL": 1,
+			"M":  2,
+			"N":  5,
+			"Ñ":  1,
+			"O":  9,
+			"P":  2,
+			"Q":  1,
+			"R":  5,
+			"RR": 1,
+			"S":  6,
+			"T":  4,
+			"U":  5,
+			"V":  1,
+			"X":  1,
+			"Y":  1,
+			"Z":  1,
+			"*":  2,
+		},
+	}
+}
+
+// GermanTileSet returns the tiles of the official German game with the tiles Ä, Ö and Ü
+func GermanTileSet() TileSet {
+	return TileSet{
+		LetterScores: map[string]int{
+			"A": 1,
+			"Ä": 6,
+			"B": 3,
+			"C": 4,
+			"D": 1,
+			"E": 1,
+			"F": 4,
+			"G": 2,
+			"H": 2,
+			"I": 1,
+			"J": 6,
+			"K": 4,
+			"L": 2,
+			"M": 3,
+			"N": 1,
+			"O": 2,
+			"Ö": 8,
+			"P": 4,
+			"Q": 10,
+			"R": 1,
+			"S": 1,
+			"T": 1,
+			"U": 1,
+			"Ü": 6,
+			"V": 6,
+			"W": 3,
+			"X": 8,
+			"Y": 10,
+			"Z": 3,
+			"*": 0,
+		},
+		TileDistribution: map[string]int{
+			"A": 5,
+			"Ä": 1,
+			"B": 2,
+			"C": 2,
+			"D": 4,
+			"E": 15,
+			"F": 2,
+			"G": 3,
+			"H": 4,
+			"I": 6,
+			"J": 1,
+			"K": 2,
+			"L": 3,
+			"M": 4,
+			"N": 9,
+			"O": 3,
+			"Ö": 1,
+			"P": 1,
+			"Q": 1,
+			"R": 6,
+			"S": 7,
+			"T": 6,
+			"U": 6,
+			"Ü": 1,
+			"V": 1,
+			"W": 1,
+			"X": 1,
+			"Y": 1,
+			"Z": 1,
+			"*": 2,
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"assets"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"math/rand"
+	"testing"
+)
+
+// newTileMove creates a move placing tiles with the given letters of the tile set starting at the given coordinates
+func newTileMove(tiles TileSet, letters []string, x int, y int, direction Direction) []Move {
+	dx, dy := direction.Step()
+	move := make([]Move, 0, len(letters))
+	for i, letter := range letters {
+		move = append(move, Move{X: x + i*dx, Y: y + i*dy, Tile: newTestTile(letter, tiles.LetterScores[letter])})
+	}
+	return move
+}
+
+func TestTileSets(t *testing.T) {
+	t.Run("Languages have full bags", func(t *testing.T) {
+		for _, test := range []struct {
+			code  string
+			tiles int
+		}{
+			{"en", 100},
+			{"fr", 102},
+			{"es", 100},
+			{"de", 102},
+		} {
+			language, err := LanguageByCode(test.code)
+			assert.NoError(t, err)
+			rules := OfficialRules()
+			rules.TileSet = language.TileSet
+			assert.Len(t, NewBagWithRuleSet(rules, rand.NewSource(1)).Tiles, test.tiles, test.code)
+			for letter := range language.TileSet.TileDistribution {
+				_, ok := language.TileSet.LetterScores[letter]
+				assert.True(t, ok, letter)
+			}
+		}
+		_, err := LanguageByCode("xx")
+		assert.ErrorIs(t, err, ErrUnknownLanguage)
+	})
+
+	t.Run("Dictionaries of all languages are bundled", func(t *testing.T) {
+		for _, language := range Languages() {
+			_, err := fs.Stat(assets.FS(), DictionaryDirectory+"/"+language.DictionaryFile)
+			assert.NoError(t, err, language.Code)
+		}
+		myGame, err := NewGame(WithSeed(1))
+		assert.NoError(t, err)
+		assert.True(t, myGame.Lexicon.IsWord("hello"))
+	})
+
+	t.Run("Words are split into the letters of the tiles", func(t *testing.T) {
+		tiles := SpanishTileSet()
+		letters, ok := tiles.SplitLetters("chorro")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"CH", "O", "RR", "O"}, letters)
+		letters, ok = tiles.SplitLetters("año")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"A", "Ñ", "O"}, letters)
+		_, ok = tiles.SplitLetters("kilo")
+		assert.False(t, ok)
+	})
+
+	t.Run("Word scores count multi-rune tiles once", func(t *testing.T) {
+		spanish := SpanishTileSet()
+		assert.Equal(t, 5+1+8+1, CalculateWordScore("chorro", &spanish))
+		german := GermanTileSet()
+		assert.Equal(t, 3+6+4+4+1, CalculateWordScore("bäckt", &german))
+		english := EnglishTileSet()
+		assert.Equal(t, 5, CalculateWordScore("cat", &english))
+	})
+
+	t.Run("Blank tiles are assigned letters of the tile set", func(t *testing.T) {
+		spanish := SpanishTileSet()
+		assert.True(t, newTestTile(BlankLetter, 0).AssignLetter("ch", &spanish))
+		assert.True(t, newTestTile(BlankLetter, 0).AssignLetter("ñ", &spanish))
+		assert.False(t, newTestTile(BlankLetter, 0).AssignLetter("K", &spanish))
+	})
+}
+
+func TestCheckMoveWithTileSet(t *testing.T) {
+	t.Run("Multi-rune tiles form words", func(t *testing.T) {
+		myGame := newTestGame("chorro")
+		myGame.Rules.TileSet = SpanishTileSet()
+		move := newTileMove(myGame.Rules.TileSet, []string{"CH", "O", "RR", "O"}, 6, 7, Horizontal)
+		result := checkMove(myGame, move)
+		assert.True(t, result.IsValid, result.String())
+		assert.Equal(t, []string{"CHORRO"}, wordStrings(result.Words))
+		assert.Equal(t, (5+1+8+1)*2, myGame.Board.ScoreMove(move).Total)
+	})
+
+	t.Run("Digraphs have to be played with their tile", func(t *testing.T) {
+		myGame := newTestGame("chorro")
+		myGame.Rules.TileSet = SpanishTileSet()
+		move := newTileMove(myGame.Rules.TileSet, []string{"C", "H", "O", "R", "R", "O"}, 6, 7, Horizontal)
+		result := checkMove(myGame, move)
+		assert.Equal(t, []RuleViolation{UnknownWords}, result.Violations)
+	})
+
+	t.Run("Umlauts are letters", func(t *testing.T) {
+		myGame := newTestGame("bär")
+		myGame.Rules.TileSet = GermanTileSet()
+		move := newTileMove(myGame.Rules.TileSet, []string{"B", "Ä", "R"}, 6, 7, Horizontal)
+		assert.True(t, checkMove(myGame, move).IsValid)
+	})
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+)
+
+// This represents the phases of a game and the order in which the players take their turns
+
+// MaxPlayers is the maximum number of players of a game
+const MaxPlayers = 4
+
+type Phase int
+
+const (
+	PhaseLobby      Phase = iota // Players join the game
+	PhaseInProgress              // Players take turns
+	PhaseFinished                // The game is over
+)
+
+func (phase Phase) String() string {
+	switch phase {
+	case PhaseLobby:
+		return "lobby"
+	case PhaseInProgress:
+		return "in progress"
+	case PhaseFinished:
+		return "finished"
+	}
+	return fmt.Sprintf("phase %d", int(phase))
+}
+
+var (
+	ErrGameNotInProgress  = errors.New("game is not in progress")
+	ErrGameAlreadyStarted = errors.New("game has already started")
+	ErrNotYourTurn        = errors.New("it is not the turn of the player")
+	ErrNoPlayers          = errors.New("game has no players")
+	ErrTooManyPlayers     = errors.New("game has too many players")
+)
+
+type TurnActions interface {
+	// AddPlayer adds a player to the game while it has not been started
+	AddPlayer(player *Player) error
+	// Start selects the starting player, fills the racks of all players and starts the game
+	Start() error
+	// IsTurn checks if it is the turn of the player
+	IsTurn(player *Player) bool
+	// CheckTurn returns an error if the player is not allowed to act, because the game is not in progress or it is not
+	// the turn of the player
+	CheckTurn(player *Player) error
+	// Pass ends the turn of the player without playing any tiles. It counts as a scoreless turn.
+	Pass(player *Player) error
+}
+
+func (game *Game) AddPlayer(player *Player) error {
+	if game.Phase != PhaseLobby {
+		return ErrGameAlreadyStarted
+	}
+	if len(game.Players) >= MaxPlayers {
+		return ErrTooManyPlayers
+	}
+	game.Players = append(game.Players, player)
+	zap.L().Debug(fmt.Sprintf("Player '%s' joined the game", player.Name))
+	game.publish(PlayerJoined{Player: player})
+	return nil
+}
+
+func (game *Game) Start() error {
+	if game.Phase != PhaseLobby {
+		return ErrGameAlreadyStarted
+	}
+	if len(game.Players) == 0 {
+		return ErrNoPlayers
+	}
+	// The starting player is drawn with the random source of the bag, so a game with a seeded bag is reproducible
+	game.CurrentPlayer = game.Players[game.Bag.random.Intn(len(game.Players))]
+	// Fill the racks in turn order, starting with the starting player
+	for range game.Players {
+		game.PullNewTilesFromBag(game.CurrentPlayer)
+		game.CurrentPlayer = game.nextPlayer()
+	}
+	game.Phase = PhaseInProgress
+	zap.L().Debug(fmt.Sprintf("Game started, player '%s' begins", game.CurrentPlayer.Name))
+	game.publish(TurnChanged{Player: game.CurrentPlayer})
+	return nil
+}
+
+func (game *Game) IsTurn(player *Player) bool {
+	return game.Phase == PhaseInProgress && game.CurrentPlayer == player
+}
+
+func (game *Game) CheckTurn(player *Player) error {
+	if game.Phase != PhaseInProgress {
+		return ErrGameNotInProgress
+	}
+	if game.CurrentPlayer != player {
+		return ErrNotYourTurn
+	}
+	if game.Pending != nil {
+		return ErrMovePending
+	}
+	return nil
+}
+
+func (game *Game) Pass(player *Player) error {
+	if err := game.CheckTurn(player); err != nil {
+		return err
+	}
+	game.ResetTemporaryMoves(player)
+	before := game.captureState(player)
+	zap.L().Debug(fmt.Sprintf("Player '%s' passed", player.Name))
+	game.publish(Passed{Player: player})
+	game.endTurn(0)
+	game.recordTurn(Turn{Kind: PassTurn, Player: player}, before)
+	return nil
+}
+
+// nextTurn hands the turn to the next player
+func (game *Game) nextTurn() {
+	game.CurrentPlayer = game.nextPlayer()
+	zap.L().Debug(fmt.Sprintf("It is the turn of player '%s'", game.CurrentPlayer.Name))
+	game.publish(TurnChanged{Player: game.CurrentPlayer})
+}
+
+// nextPlayer returns the player following the current player in turn order
+func (game *Game) nextPlayer() *Player {
+	for i, player := range game.Players {
+		if player == game.CurrentPlayer {
+			return game.Players[(i+1)%len(game.Players)]
+		}
+	}
+	return game.Players[0]
+}
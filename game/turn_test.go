@@ -0,0 +1,179 @@
+package game
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestLobby(names ...string) *Game {
+	myGame := newTestGame("cat")
+	myGame.Players = []*Player{}
+	myGame.CurrentPlayer = nil
+	myGame.Phase = PhaseLobby
+	for _, name := range names {
+		_ = myGame.AddPlayer(NewPlayer(name))
+	}
+	return myGame
+}
+
+func TestTurns(t *testing.T) {
+	t.Run("Start selects a starting player and fills all racks", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		assert.Equal(t, PhaseInProgress, myGame.Phase)
+		assert.Contains(t, myGame.Players, myGame.CurrentPlayer)
+		for _, player := range myGame.Players {
+			assert.Len(t, player.Tiles, RackSize)
+		}
+		assert.Equal(t, ErrGameAlreadyStarted, myGame.Start())
+		assert.Equal(t, ErrGameAlreadyStarted, myGame.AddPlayer(NewPlayer("Carol")))
+	})
+
+	t.Run("Game needs players", func(t *testing.T) {
+		assert.Equal(t, ErrNoPlayers, newTestLobby().Start())
+		assert.Equal(t, ErrTooManyPlayers, newTestLobby("A", "B", "C", "D").AddPlayer(NewPlayer("E")))
+	})
+
+	t.Run("Turns rotate after passing", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob", "Carol")
+		assert.Equal(t, ErrGameNotInProgress, myGame.Pass(myGame.Players[0]))
+		assert.NoError(t, myGame.Start())
+		first := myGame.CurrentPlayer
+		order := []*Player{first}
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+			order = append(order, myGame.CurrentPlayer)
+		}
+		assert.Equal(t, first, order[3])
+		assert.ElementsMatch(t, myGame.Players, order[:3])
+	})
+
+	t.Run("Actions of other players are rejected", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		other := myGame.nextPlayer()
+		assert.False(t, myGame.IsTurn(other))
+		assert.Equal(t, ErrNotYourTurn, myGame.Pass(other))
+		myGame.AddTemporaryMove(other, Move{X: 7, Y: 7, Tile: &other.Tiles[0]})
+		_, result := myGame.PlayTemporaryMoves(other)
+		assert.Equal(t, []RuleViolation{NotYourTurn}, result.Violations)
+	})
+
+	t.Run("Turn passes after playing a move", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		player.Tiles = []Tile{*newTestTile("C", 3), *newTestTile("A", 1), *newTestTile("T", 1)}
+		for i, x := range []int{6, 7, 8} {
+			myGame.AddTemporaryMove(player, Move{X: x, Y: 7, Tile: &player.Tiles[i]})
+		}
+		score, result := myGame.PlayTemporaryMoves(player)
+		assert.True(t, result.IsValid)
+		assert.Equal(t, 10, score.Total)
+		assert.Len(t, player.Tiles, RackSize)
+		assert.NotEqual(t, player, myGame.CurrentPlayer)
+	})
+}
+
+func TestExchangeTiles(t *testing.T) {
+	t.Run("Exchanged tiles are replaced and the turn passes", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		bagSize := len(myGame.Bag.Tiles)
+		kept := append([]Tile{}, player.Tiles[2:]...)
+		assert.NoError(t, myGame.ExchangeTiles(player, []Tile{player.Tiles[0], player.Tiles[1]}))
+		assert.Len(t, player.Tiles, RackSize)
+		assert.Equal(t, kept, player.Tiles[:RackSize-2])
+		assert.Len(t, myGame.Bag.Tiles, bagSize)
+		assert.False(t, myGame.IsTurn(player))
+	})
+
+	t.Run("Exchange needs enough tiles in the bag", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		myGame.Bag.Tiles = myGame.Bag.Tiles[:ExchangeMinimum-1]
+		player := myGame.CurrentPlayer
+		assert.Equal(t, ErrNotEnoughTilesInBag, myGame.ExchangeTiles(player, player.Tiles[:1]))
+		assert.True(t, myGame.IsTurn(player))
+	})
+
+	t.Run("Only rack tiles of the current player can be exchanged", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		player := myGame.CurrentPlayer
+		assert.Equal(t, ErrNoTilesSelected, myGame.ExchangeTiles(player, []Tile{}))
+		assert.Equal(t, ErrNotYourTurn, myGame.ExchangeTiles(myGame.nextPlayer(), player.Tiles[:1]))
+		player.Tiles = []Tile{*newTestTile("A", 1)}
+		assert.Equal(t, ErrTilesNotInRack, myGame.ExchangeTiles(player, []Tile{*newTestTile("Q", 10)}))
+	})
+}
+
+func TestEndOfGame(t *testing.T) {
+	t.Run("Game ends after too many scoreless turns", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		for i := 0; i < MaxScorelessTurns; i++ {
+			assert.Equal(t, PhaseInProgress, myGame.Phase)
+			assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		}
+		assert.Equal(t, PhaseFinished, myGame.Phase)
+		assert.Equal(t, ErrGameNotInProgress, myGame.Pass(myGame.CurrentPlayer))
+		standings := myGame.Standings()
+		assert.Equal(t, TooManyScoreless, standings.Reason)
+		assert.Nil(t, standings.WentOut)
+		for _, standing := range standings.Players {
+			assert.Less(t, standing.Adjustment, 0)
+			assert.Equal(t, standing.Adjustment, standing.Player.Score)
+		}
+	})
+
+	t.Run("Scoring play resets the scoreless turns", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		assert.NoError(t, myGame.Pass(myGame.CurrentPlayer))
+		player := myGame.CurrentPlayer
+		player.Tiles = []Tile{*newTestTile("C", 3), *newTestTile("A", 1), *newTestTile("T", 1)}
+		for i, x := range []int{6, 7, 8} {
+			myGame.AddTemporaryMove(player, Move{X: x, Y: 7, Tile: &player.Tiles[i]})
+		}
+		_, result := myGame.PlayTemporaryMoves(player)
+		assert.True(t, result.IsValid)
+		assert.Equal(t, 0, myGame.ScorelessTurns)
+	})
+
+	t.Run("Player going out receives the leftovers of the opponents", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob")
+		assert.NoError(t, myGame.Start())
+		myGame.Bag.Tiles = []Tile{}
+		player := myGame.CurrentPlayer
+		opponent := myGame.nextPlayer()
+		player.Tiles = []Tile{*newTestTile("C", 3), *newTestTile("A", 1), *newTestTile("T", 1)}
+		opponent.Tiles = []Tile{*newTestTile("Q", 10), *newTestTile("E", 1)}
+		for i, x := range []int{6, 7, 8} {
+			myGame.AddTemporaryMove(player, Move{X: x, Y: 7, Tile: &player.Tiles[i]})
+		}
+		_, result := myGame.PlayTemporaryMoves(player)
+		assert.True(t, result.IsValid)
+		assert.Equal(t, PhaseFinished, myGame.Phase)
+		standings := myGame.Standings()
+		assert.Equal(t, PlayerWentOut, standings.Reason)
+		assert.Equal(t, player, standings.WentOut)
+		assert.Equal(t, []*Player{player}, standings.Winners())
+		assert.Equal(t, Standing{Player: player, Rank: 1, Score: 10 + 11, Adjustment: 11, RemainingTiles: []Tile{}},
+			standings.Players[0])
+		assert.Equal(t, -11, standings.Players[1].Score)
+		assert.Equal(t, fmt.Sprintf("1. %s 21 (+11)\n2. %s -11 (-11)", player.Name, opponent.Name), standings.String())
+	})
+
+	t.Run("Players with equal scores share a rank", func(t *testing.T) {
+		myGame := newTestLobby("Alice", "Bob", "Carol")
+		myGame.Players[0].Score = 5
+		myGame.Players[1].Score = 7
+		myGame.Players[2].Score = 5
+		standings := myGame.Standings()
+		assert.Equal(t, []int{1, 2, 2}, []int{standings.Players[0].Rank, standings.Players[1].Rank, standings.Players[2].Rank})
+		assert.Equal(t, "Bob", standings.Players[0].Player.Name)
+	})
+}
@@ -0,0 +1,171 @@
+package game
+
+import "strings"
+
+// This represents words formed on the board by a move
+
+// Direction of a move or word on the board
+type Direction int
+
+const (
+	Horizontal Direction = iota // Along the x axis
+	Vertical                    // Along the y axis
+)
+
+func (direction Direction) String() string {
+	if direction == Vertical {
+		return "vertical"
+	}
+	return "horizontal"
+}
+
+// Perpendicular returns the direction perpendicular to this one
+func (direction Direction) Perpendicular() Direction {
+	if direction == Vertical {
+		return Horizontal
+	}
+	return Vertical
+}
+
+// Step returns the x and y increments to walk one field along the direction
+func (direction Direction) Step() (int, int) {
+	if direction == Vertical {
+		return 0, 1
+	}
+	return 1, 0
+}
+
+// WordTile is a tile of a word along with its coordinates
+type WordTile struct {
+	Move
+	// Placed is true if the tile is part of the move which formed the word and false if it was already on the board
+	Placed bool
+}
+
+// Word is a word formed by a move. It consists of the newly placed tiles and all adjacent tiles already on the board in
+// reading order.
+type Word struct {
+	Direction Direction
+	Tiles     []WordTile
+}
+
+func (word Word) String() string {
+	var builder strings.Builder
+	for _, tile := range word.Tiles {
+		builder.WriteString(tile.Tile.PlayedLetter())
+	}
+	return builder.String()
+}
+
+// Contains checks if the word covers the field at the given coordinates
+func (word Word) Contains(x int, y int) bool {
+	for _, tile := range word.Tiles {
+		if tile.X == x && tile.Y == y {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveDirection determines the direction of a move. All tiles of a move have to be in a straight line, otherwise the
+// second return value is false. A single tile is considered horizontal, unless it only touches tiles in vertical
+// direction.
+func (r *Board) MoveDirection(move []Move) (Direction, bool) {
+	if len(move) == 0 {
+		return Horizontal, false
+	}
+	if len(move) == 1 {
+		placed := movesByPosition(move)
+		if len(r.wordAt(move[0].X, move[0].Y, Horizontal, placed).Tiles) == 1 &&
+			len(r.wordAt(move[0].X, move[0].Y, Vertical, placed).Tiles) > 1 {
+			return Vertical, true
+		}
+		return Horizontal, true
+	}
+	sameX, sameY := true, true
+	for _, m := range move[1:] {
+		sameX = sameX && m.X == move[0].X
+		sameY = sameY && m.Y == move[0].Y
+	}
+	if sameY {
+		return Horizontal, true
+	}
+	if sameX {
+		return Vertical, true
+	}
+	return Horizontal, false
+}
+
+// FormedWords returns all words formed by the move: The main word along the direction of the move and every cross
+// word perpendicular to it. Single letters are not considered words. The second return value is false if the tiles
+// are not in a straight line or if there is a gap between them which is not filled by tiles on the board.
+func (r *Board) FormedWords(move []Move) ([]Word, bool) {
+	direction, ok := r.MoveDirection(move)
+	if !ok {
+		return nil, false
+	}
+	placed := movesByPosition(move)
+	words := make([]Word, 0)
+	mainWord := r.wordAt(move[0].X, move[0].Y, direction, placed)
+	for _, m := range move {
+		if !mainWord.Contains(m.X, m.Y) {
+			return nil, false
+		}
+	}
+	if len(mainWord.Tiles) > 1 {
+		words = append(words, mainWord)
+	}
+	for _, m := range move {
+		crossWord := r.wordAt(m.X, m.Y, direction.Perpendicular(), placed)
+		if len(crossWord.Tiles) > 1 {
+			words = append(words, crossWord)
+		}
+	}
+	return words, true
+}
+
+// wordAt collects the contiguous tiles through the given coordinates in the given direction. Tiles of the move being
+// checked take precedence over tiles on the board.
+func (r *Board) wordAt(x int, y int, direction Direction, placed map[[2]int]*Tile) Word {
+	dx, dy := direction.Step()
+	// Walk back to the first tile of the word
+	for {
+		if _, _, ok := r.tileAt(x-dx, y-dy, placed); !ok {
+			break
+		}
+		x, y = x-dx, y-dy
+	}
+	word := Word{Direction: direction, Tiles: make([]WordTile, 0)}
+	for {
+		tile, isPlaced, ok := r.tileAt(x, y, placed)
+		if !ok {
+			break
+		}
+		word.Tiles = append(word.Tiles, WordTile{
+			Move:   Move{X: x, Y: y, Tile: tile},
+			Placed: isPlaced,
+		})
+		x, y = x+dx, y+dy
+	}
+	return word
+}
+
+// tileAt returns the tile at the given coordinates and whether it is part of the placed tiles
+func (r *Board) tileAt(x int, y int, placed map[[2]int]*Tile) (*Tile, bool, bool) {
+	if tile, ok := placed[[2]int{x, y}]; ok {
+		return tile, true, true
+	}
+	field, ok := r.GetField(x, y)
+	if !ok || field.Tile == nil {
+		return nil, false, false
+	}
+	return field.Tile, false, true
+}
+
+func movesByPosition(move []Move) map[[2]int]*Tile {
+	placed := make(map[[2]int]*Tile, len(move))
+	for _, m := range move {
+		placed[[2]int{m.X, m.Y}] = m.Tile
+	}
+	return placed
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"config"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -10,6 +11,7 @@ import (
 	"game"
 	"go.uber.org/zap"
 	"image/color"
+	"sync"
 )
 
 type BoardWidget struct {
@@ -22,6 +24,14 @@ type BoardWidget struct {
 	tilesByIndex        map[int]*game.Tile
 	numColumns          int
 	numRows             int
+	// mu guards the cells and tilesByIndex, which are changed by the drag handlers on the UI thread and by the events
+	// of the game on the goroutine of the subscriber. It is locked before the game, never while viewing the game.
+	mu sync.Mutex
+	// OnBlankPlaced is called when a blank tile is dropped on the board. The letter has to be assigned to the tile
+	// before calling assigned.
+	OnBlankPlaced func(tile *game.Tile, assigned func())
+	// Player is the player whose rack is shown and who places the tiles
+	Player *game.Player
 }
 
 type BoardRenderer struct {
@@ -38,7 +48,9 @@ func (b BoardRenderer) Layout(size fyne.Size) {
 }
 
 func (b BoardRenderer) MinSize() fyne.Size {
-	return fyne.NewSize(CellWidth*16, CellHeight*17)
+	numCols := b.BoardWidget.numColumns + NumIndexCols
+	numRows := b.BoardWidget.numRows + NumIndexRows + NumRackRows
+	return fyne.NewSize(CellWidth*float32(numCols), CellHeight*float32(numRows))
 }
 
 func (b BoardRenderer) Objects() []fyne.CanvasObject {
@@ -76,6 +88,8 @@ func (b *BoardWidget) CreateRenderer() fyne.WidgetRenderer {
 }
 
 func (b *BoardWidget) Dragged(event *fyne.DragEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	dragPosition := event.Position
 	if !b.IsDragging {
 		if b.tileDragger.OnDragStart(dragPosition) {
@@ -89,6 +103,8 @@ func (b *BoardWidget) Dragged(event *fyne.DragEvent) {
 }
 
 func (b *BoardWidget) DragEnd() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	if b.IsDragging {
 		b.IsDragging = false
 		b.tileDragger.OnDragEnd(b.CurrentDragPosition)
@@ -96,9 +112,11 @@ func (b *BoardWidget) DragEnd() {
 	zap.S().Info("DragEnd")
 }
 
-func NewBoardWidget(myGame *game.Game) *BoardWidget {
-	numBoardCols := 15
-	numBoardRows := 15
+// NewBoardWidget creates the board with the rack of the player, who plays the game with this widget
+func NewBoardWidget(myGame *game.Game, player *game.Player, boardTheme config.Theme) *BoardWidget {
+	// The size of the board depends on the rules of the game
+	numBoardCols := len(myGame.Board.Fields)
+	numBoardRows := len(myGame.Board.Fields[0])
 	numCols := numBoardCols + NumIndexCols
 	numRows := numBoardRows + NumIndexRows + NumRackRows
 	cellStacks := make([]fyne.CanvasObject, numCols*numRows)
@@ -113,9 +131,9 @@ func NewBoardWidget(myGame *game.Game) *BoardWidget {
 				fieldColor.StrokeColor = color.Black
 				fieldColor.StrokeWidth = 1
 				stack := container.NewStack(fieldColor)
-				// Add tilesWidgets to stack for the first 7 tiles
-				if i < 7 {
-					tile := &myGame.CurrentPlayer.Tiles[i]
+				// Add tilesWidgets to stack for the tiles of the rack
+				if i < len(player.Tiles) {
+					tile := &player.Tiles[i]
 					tilesByIndex[cellIndex] = tile
 					tileWidget := NewTileWidget(tile, myGame)
 					stack.Add(tileWidget)
@@ -148,7 +166,7 @@ func NewBoardWidget(myGame *game.Game) *BoardWidget {
 				continue
 			}
 
-			// Row labels "1" to "15"
+			// Row labels starting at "1"
 			if j == 0 && i > 0 {
 				label := canvas.NewText(fmt.Sprintf("%d", i), color.Black)
 				label.Alignment = fyne.TextAlignCenter
@@ -175,7 +193,7 @@ func NewBoardWidget(myGame *game.Game) *BoardWidget {
 
 			field, _ := myGame.Board.GetField(fieldX, fieldY)
 
-			fieldColor, fieldText := CrateFieldStackComponents(field)
+			fieldColor, fieldText := CrateFieldStackComponents(field, boardTheme)
 			stack := container.NewStack(fieldColor, fieldText)
 			cellStacks[cellIndex] = stack
 		}
@@ -183,6 +201,7 @@ func NewBoardWidget(myGame *game.Game) *BoardWidget {
 
 	boardWidget := &BoardWidget{
 		Board:        myGame.Board,
+		Player:       player,
 		Container:    *container.New(layout.NewGridLayout(numCols), cellStacks...),
 		numColumns:   numBoardCols,
 		numRows:      numBoardRows,
@@ -193,30 +212,148 @@ func NewBoardWidget(myGame *game.Game) *BoardWidget {
 	return boardWidget
 }
 
+// MarkedTiles returns the tiles on the rack which are marked for exchange
+func (b *BoardWidget) MarkedTiles() []*game.Tile {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	marked := make([]*game.Tile, 0)
+	rackRow := b.numRows + NumIndexRows
+	for i := 0; i < b.numColumns+NumIndexCols; i++ {
+		cell, ok := b.Container.Objects[XY2I(i, rackRow, b.numColumns+NumIndexCols)].(*fyne.Container)
+		if !ok {
+			continue
+		}
+		for _, object := range cell.Objects {
+			if tileWidget, ok := object.(*TileWidget); ok && tileWidget.Marked {
+				marked = append(marked, tileWidget.Tile)
+			}
+		}
+	}
+	return marked
+}
+
+// refreshRack replaces the tiles on the rack with the tiles of the player, e.g. after exchanging tiles. Tiles placed on
+// the board but not played yet are left out. The caller has to hold the lock of the board.
+func (b *BoardWidget) refreshRack(myGame *game.Game, player *game.Player) {
+	placed := make(map[*game.Tile]bool, len(myGame.TemporaryMoves[player]))
+	for _, move := range myGame.TemporaryMoves[player] {
+		placed[move.Tile] = true
+	}
+	rackRow := b.numRows + NumIndexRows
+	for i := 0; i < b.numColumns+NumIndexCols; i++ {
+		cellIndex := XY2I(i, rackRow, b.numColumns+NumIndexCols)
+		cell, ok := b.Container.Objects[cellIndex].(*fyne.Container)
+		if !ok {
+			continue
+		}
+		cell.Objects = cell.Objects[:1]
+		delete(b.tilesByIndex, cellIndex)
+		if i < len(player.Tiles) && !placed[&player.Tiles[i]] {
+			tile := &player.Tiles[i]
+			b.tilesByIndex[cellIndex] = tile
+			cell.Add(NewTileWidget(tile, myGame))
+		}
+	}
+	b.Refresh()
+}
+
+// showGame shows the tiles on the board of the game and the rack of the player, e.g. after taking back a turn. Tiles
+// which were placed on the board but not played are shown on the board, so they can be played or taken back. The
+// caller has to hold the lock of the board.
+func (b *BoardWidget) showGame(myGame *game.Game, player *game.Player) {
+	for i := 0; i < b.numColumns; i++ {
+		for j := 0; j < b.numRows; j++ {
+			cellIndex := XY2I(i+NumIndexCols, j+NumIndexRows, b.numColumns+NumIndexCols)
+			cell, ok := b.Container.Objects[cellIndex].(*fyne.Container)
+			if !ok {
+				continue
+			}
+			objects := make([]fyne.CanvasObject, 0, len(cell.Objects))
+			for _, object := range cell.Objects {
+				if _, isTile := object.(*TileWidget); !isTile {
+					objects = append(objects, object)
+				}
+			}
+			cell.Objects = objects
+			delete(b.tilesByIndex, cellIndex)
+			if field, ok := myGame.Board.GetField(i, j); ok && field.Tile != nil {
+				cell.Add(NewTileWidget(field.Tile, myGame))
+			}
+		}
+	}
+	for _, move := range myGame.TemporaryMoves[player] {
+		cellIndex := XY2I(move.X+NumIndexCols, move.Y+NumIndexRows, b.numColumns+NumIndexCols)
+		if cell, ok := b.Container.Objects[cellIndex].(*fyne.Container); ok && myGame.Board.IsFieldEmpty(move.X, move.Y) {
+			b.tilesByIndex[cellIndex] = move.Tile
+			cell.Add(NewTileWidget(move.Tile, myGame))
+		}
+	}
+	b.refreshRack(myGame, player)
+}
+
+// PreviewHint places the tiles of the hint on the board as temporary moves of the player without playing them
+func (b *BoardWidget) PreviewHint(hint game.Hint) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	myGame := b.tileDragger.Game
+	if err := myGame.Execute(game.PreviewHintCommand{Player: b.Player, Hint: hint}); err != nil {
+		return err
+	}
+	myGame.View(func(myGame *game.Game) {
+		b.showGame(myGame, b.Player)
+	})
+	return nil
+}
+
+// HandleEvent updates the board and the rack from the events of the game
+func (b *BoardWidget) HandleEvent(event game.Event) {
+	myGame := b.tileDragger.Game
+	switch event := event.(type) {
+	case game.MovePlayed, game.MovePending, game.MoveChallenged, game.TurnTakenBack, game.TurnRedone:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		myGame.View(func(myGame *game.Game) {
+			b.showGame(myGame, b.Player)
+		})
+	case game.TilesDrawn:
+		if event.Player == b.Player {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			myGame.View(func(myGame *game.Game) {
+				b.refreshRack(myGame, b.Player)
+			})
+		}
+	}
+}
+
 func IntToRGBA(i int) color.RGBA {
 	return color.RGBA{R: uint8(i >> 16), G: uint8(i >> 8), B: uint8(i), A: 0xff}
 }
 
-func FieldTypeToColor(field game.Field) color.Color {
+func FieldTypeToColor(field game.Field, boardTheme config.Theme) color.Color {
 	switch field.Type {
 	case game.NF:
-		return IntToRGBA(game.NFColor)
+		return IntToRGBA(boardTheme.NFColor)
 	case game.DL:
-		return IntToRGBA(game.DLColor)
+		return IntToRGBA(boardTheme.DLColor)
 	case game.TL:
-		return IntToRGBA(game.TLColor)
+		return IntToRGBA(boardTheme.TLColor)
 	case game.DW:
-		return IntToRGBA(game.DWColor)
+		return IntToRGBA(boardTheme.DWColor)
 	case game.TW:
-		return IntToRGBA(game.TWColor)
+		return IntToRGBA(boardTheme.TWColor)
 	case game.CS:
-		return IntToRGBA(game.CSColor)
+		return IntToRGBA(boardTheme.CSColor)
+	case game.QL:
+		return IntToRGBA(boardTheme.QLColor)
+	case game.QW:
+		return IntToRGBA(boardTheme.QWColor)
 	}
 	return color.White
 }
 
-func CrateFieldStackComponents(field game.Field) (*canvas.Rectangle, *canvas.Text) {
-	cellColor := canvas.NewRectangle(FieldTypeToColor(field))
+func CrateFieldStackComponents(field game.Field, boardTheme config.Theme) (*canvas.Rectangle, *canvas.Text) {
+	cellColor := canvas.NewRectangle(FieldTypeToColor(field, boardTheme))
 	cellColor.StrokeColor = color.Black
 	cellColor.StrokeWidth = 1
 
@@ -234,6 +371,10 @@ func CrateFieldStackComponents(field game.Field) (*canvas.Rectangle, *canvas.Tex
 		fieldText.Text = "3W"
 	case game.CS:
 		fieldText.Text = "*"
+	case game.QL:
+		fieldText.Text = "4L"
+	case game.QW:
+		fieldText.Text = "4W"
 	}
 	fieldText.Alignment = fyne.TextAlignCenter
 	fieldText.TextStyle = fyne.TextStyle{Bold: true}
@@ -287,6 +428,12 @@ type TileDraggerHandler interface {
 	IsInBoardArea(position fyne.Position) bool
 	// IsCellEmpty checks if the cell at the given position is empty
 	IsCellEmpty(position fyne.Position) bool
+	// AssignBlankLetter lets the player choose the letter of the blank tile at the given position
+	AssignBlankLetter(position fyne.Position)
+	// PlaceTile adds the dragged tile at the given board position to the temporary moves of the player
+	PlaceTile(position fyne.Position) bool
+	// TakeBackTile removes the dragged tile taken from the given board position from the temporary moves
+	TakeBackTile(position fyne.Position)
 
 	// GetTileWidgetByPosition returns the tile widget at the given position
 	GetTileWidgetByPosition(position fyne.Position) (*TileWidget, bool)
@@ -317,7 +464,7 @@ func NewTileDragger(board *BoardWidget, game *game.Game) *TileDragger {
 
 func (d *TileDragger) GetTileByPosition(position fyne.Position) *game.Tile {
 	x, y := d.GetCellIndexByPosition(position)
-	index := XY2I(x, y, 16)
+	index := XY2I(x, y, d.Board.numColumns+NumIndexCols)
 	tile := d.Board.tilesByIndex[index]
 	zap.L().Debug(fmt.Sprintf("Got tile %v from pos %d,%d at index %d", tile, x, y, index))
 	return tile
@@ -357,6 +504,9 @@ func (d *TileDragger) OnDragEnd(position fyne.Position) {
 		zap.L().Debug("Dropped in rack area")
 		if d.IsCellEmpty(x, y) {
 			zap.L().Debug("Dropped on empty cell")
+			d.TakeBackTile(d.PreviousPosition)
+			// Blank tiles taken back to the rack lose their assigned letter
+			d.Tile.ResetAssignment()
 			d.AddTileToCell(d.Tile, position)
 			d.SwapTiles(position, d.PreviousPosition)
 		} else {
@@ -365,10 +515,13 @@ func (d *TileDragger) OnDragEnd(position fyne.Position) {
 		}
 	} else if d.IsBoardCell(x, y) {
 		zap.L().Debug("Dropped in board area")
-		if d.IsCellEmpty(x, y) {
+		if d.IsCellEmpty(x, y) && d.PlaceTile(position) {
 			zap.L().Debug("Dropped on empty cell")
 			d.AddTileToCell(d.Tile, position)
 			d.SwapTiles(position, d.PreviousPosition)
+			if d.Tile.IsBlank() {
+				d.AssignBlankLetter(position)
+			}
 		} else {
 			zap.L().Debug("Dropped on non empty cell")
 			d.AddTileToCell(d.Tile, d.PreviousPosition)
@@ -384,12 +537,44 @@ func (d *TileDragger) OnDragEnd(position fyne.Position) {
 	d.PreviousCell = nil
 }
 
+// PlaceTile adds the dragged tile at the given board position to the temporary moves of the player. It returns false
+// if the game rejects the tile, e.g. because it is not the player's turn.
+func (d *TileDragger) PlaceTile(position fyne.Position) bool {
+	x, y := d.GetCellIndexByPosition(position)
+	move := game.Move{X: x - NumIndexCols, Y: y - NumIndexRows, Tile: d.Tile}
+	if err := d.Game.Execute(game.PlaceTileCommand{Player: d.Board.Player, Move: move}); err != nil {
+		zap.L().Debug("Cannot place tile", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// TakeBackTile removes the dragged tile from the temporary moves of the player if it was taken from the board at the
+// given position
+func (d *TileDragger) TakeBackTile(position fyne.Position) {
+	x, y := d.GetCellIndexByPosition(position)
+	if !d.IsBoardCell(x, y) {
+		return
+	}
+	move := game.Move{X: x - NumIndexCols, Y: y - NumIndexRows, Tile: d.Tile}
+	_ = d.Game.Execute(game.TakeBackTileCommand{Player: d.Board.Player, Move: move})
+}
+
+// AssignBlankLetter lets the player choose the letter of the blank tile at the given position
+func (d *TileDragger) AssignBlankLetter(position fyne.Position) {
+	tileWidget, ok := d.GetTileWidgetByPosition(position)
+	if !ok || d.Board.OnBlankPlaced == nil {
+		return
+	}
+	d.Board.OnBlankPlaced(tileWidget.Tile, tileWidget.UpdateLetter)
+}
+
 func (d *TileDragger) IsRackCell(x int, y int) bool {
-	return y == 16 && x >= 1 && x <= 15
+	return y == d.Board.numRows+NumIndexRows && x >= 1 && x <= d.Board.numColumns
 }
 
 func (d *TileDragger) IsBoardCell(x int, y int) bool {
-	return y >= 1 && y <= 15 && x >= 1 && x <= 15
+	return y >= 1 && y <= d.Board.numRows && x >= 1 && x <= d.Board.numColumns
 }
 
 func (d *TileDragger) IsCellEmpty(x int, y int) bool {
@@ -424,8 +609,10 @@ func (d *TileDragger) GetTileWidgetByPosition(position fyne.Position) (*TileWidg
 
 func (d *TileDragger) GetCellIndexByPosition(position fyne.Position) (int, int) {
 	effectiveBoardSize := d.Board.Size()
-	width := uint16((effectiveBoardSize.Width + theme.Padding()) / 16.0)
-	height := uint16((effectiveBoardSize.Height + theme.Padding()) / 17.0)
+	numCols := float32(d.Board.numColumns + NumIndexCols)
+	numRows := float32(d.Board.numRows + NumIndexRows + NumRackRows)
+	width := uint16((effectiveBoardSize.Width + theme.Padding()) / numCols)
+	height := uint16((effectiveBoardSize.Height + theme.Padding()) / numRows)
 	x, y := P2C(position, width, height)
 	//zap.L().Debug(
 	//	fmt.Sprintf(
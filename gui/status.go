@@ -0,0 +1,76 @@
+package gui
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"game"
+)
+
+// StatusWidget shows the name and points of the player, whose turn it is and how many tiles are left in the bag. It is
+// kept up to date by the events of the game, see HandleEvent.
+type StatusWidget struct {
+	fyne.Container
+	Player              *game.Player
+	nameLabel           *widget.Label
+	pointsLabel         *widget.Label
+	turnLabel           *widget.Label
+	remainingTilesLabel *widget.Label
+}
+
+func NewStatusWidget(myGame *game.Game, player *game.Player) *StatusWidget {
+	statusWidget := &StatusWidget{
+		Player:              player,
+		nameLabel:           widget.NewLabel(fmt.Sprintf("Dein Name: %s", player.Name)),
+		pointsLabel:         widget.NewLabel(""),
+		turnLabel:           widget.NewLabel(""),
+		remainingTilesLabel: widget.NewLabel(""),
+	}
+	myGame.View(func(myGame *game.Game) {
+		statusWidget.setPoints(player.Score)
+		statusWidget.setRemainingTiles(len(myGame.Bag.Tiles))
+		if myGame.CurrentPlayer != nil {
+			statusWidget.setTurn(myGame.CurrentPlayer)
+		}
+	})
+	statusWidget.Container = *container.NewVBox(
+		statusWidget.nameLabel,
+		statusWidget.pointsLabel,
+		statusWidget.turnLabel,
+		statusWidget.remainingTilesLabel,
+	)
+	return statusWidget
+}
+
+// HandleEvent updates the labels from the events of the game
+func (s *StatusWidget) HandleEvent(event game.Event) {
+	switch event := event.(type) {
+	case game.ScoreChanged:
+		if event.Player == s.Player {
+			s.setPoints(event.Score)
+		}
+	case game.TurnChanged:
+		s.setTurn(event.Player)
+	case game.TilesDrawn:
+		s.setRemainingTiles(event.TilesInBag)
+	case game.TurnTakenBack:
+		s.setRemainingTiles(event.TilesInBag)
+	case game.TurnRedone:
+		s.setRemainingTiles(event.TilesInBag)
+	case game.GameEnded:
+		s.turnLabel.SetText("Spielende")
+	}
+}
+
+func (s *StatusWidget) setPoints(points int) {
+	s.pointsLabel.SetText(fmt.Sprintf("Deine Punkte: %d", points))
+}
+
+func (s *StatusWidget) setTurn(player *game.Player) {
+	s.turnLabel.SetText(fmt.Sprintf("Am Zug: %s", player.Name))
+}
+
+func (s *StatusWidget) setRemainingTiles(count int) {
+	s.remainingTilesLabel.SetText(fmt.Sprintf("Verbleibende Steine: %d", count))
+}
@@ -0,0 +1,69 @@
+package gui
+
+import (
+	"fmt"
+	"game"
+	"strings"
+)
+
+// HintText describes the hint for the hint dialog
+func HintText(hint game.Hint) string {
+	text := fmt.Sprintf("%s für %d Punkte", decisionText(game.Decision{Move: hint.Placement.Move}),
+		hint.Placement.Score.Total)
+	if len(hint.Leave) == 0 {
+		return text
+	}
+	leave := make([]string, len(hint.Leave))
+	for i, tile := range hint.Leave {
+		leave[i] = tile.Letter
+	}
+	return fmt.Sprintf("%s, Rest '%s'", text, strings.Join(leave, ""))
+}
+
+// EndgameText describes the solution of the end game solver for the end game dialog, one turn per line
+func EndgameText(solution game.EndgameSolution) string {
+	lines := make([]string, 0, len(solution.Turns)+1)
+	quality := fmt.Sprintf("%d Züge durchsucht", solution.Depth)
+	if solution.Complete {
+		quality = "vollständig durchsucht"
+	}
+	lines = append(lines, fmt.Sprintf("Vorsprung %+d (%s)", solution.Spread, quality))
+	for _, turn := range solution.Turns {
+		if len(turn.Decision.Move) == 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", turn.Player.Name, decisionText(turn.Decision)))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s für %d Punkte", turn.Player.Name, decisionText(turn.Decision),
+			turn.Score))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// decisionText describes the decision with the names of the fields as labeled on the board
+func decisionText(decision game.Decision) string {
+	if len(decision.Move) == 0 {
+		if len(decision.Exchange) > 0 {
+			return fmt.Sprintf("%d Steine tauschen", len(decision.Exchange))
+		}
+		return "Passen"
+	}
+	letters := ""
+	for _, move := range decision.Move {
+		letters += move.Tile.PlayedLetter()
+	}
+	first := decision.Move[0]
+	if len(decision.Move) == 1 {
+		return fmt.Sprintf("'%s' auf %s", letters, fieldName(first.X, first.Y))
+	}
+	direction := "senkrecht"
+	if decision.Move[1].Y == first.Y {
+		direction = "waagerecht"
+	}
+	return fmt.Sprintf("'%s' ab %s %s", letters, fieldName(first.X, first.Y), direction)
+}
+
+// fieldName returns the name of the field as labeled on the board, the letter of the row followed by the number of the
+// column, e.g. "H8" for the center field of the official board
+func fieldName(x int, y int) string {
+	return fmt.Sprintf("%c%d", 'A'+y, x+1)
+}
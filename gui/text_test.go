@@ -0,0 +1,38 @@
+package gui
+
+import (
+	"game"
+	"github.com/magiconair/properties/assert"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	t.Run("Hints name the fields as labeled on the board", func(t *testing.T) {
+		hint := game.Hint{
+			Placement: game.Placement{
+				Move: []game.Move{
+					{X: 6, Y: 7, Tile: game.NewTile("A", 1)},
+					{X: 7, Y: 7, Tile: game.NewTile("T", 1)},
+				},
+				Score: game.MoveScore{Total: 4},
+			},
+			Leave: []game.Tile{*game.NewTile("E", 1)},
+		}
+		assert.Equal(t, HintText(hint), "'AT' ab H7 waagerecht für 4 Punkte, Rest 'E'")
+	})
+
+	t.Run("End game solutions list the turns of both players", func(t *testing.T) {
+		player, opponent := game.NewPlayer("Anna"), game.NewPlayer("Ben")
+		solution := game.EndgameSolution{
+			Turns: []game.EndgameTurn{
+				{Player: player, Decision: game.Decision{}},
+				{Player: opponent, Decision: game.Decision{Move: []game.Move{{X: 7, Y: 8, Tile: game.NewTile("S", 1)}}}, Score: 6},
+			},
+			Spread:   -26,
+			Depth:    2,
+			Complete: true,
+		}
+		expected := "Vorsprung -26 (vollständig durchsucht)\nAnna: Passen\nBen: 'S' auf I8 für 6 Punkte"
+		assert.Equal(t, EndgameText(solution), expected)
+	})
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"assets"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -8,6 +9,7 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 	"game"
+	"go.uber.org/zap"
 	"image/color"
 )
 
@@ -16,6 +18,10 @@ type TileWidget struct {
 	Tile          *game.Tile
 	Objects       []fyne.CanvasObject
 	gameReference *game.Game
+	tileText      *canvas.Text
+	// Marked is true if the tile is marked for exchange
+	Marked      bool
+	markOverlay *canvas.Rectangle
 }
 
 type TileWidgetRenderer struct {
@@ -65,28 +71,79 @@ func (b *TileWidget) CreateRenderer() fyne.WidgetRenderer {
 	}
 }
 
+// SecondaryTapped toggles the exchange mark of the tile
+func (b *TileWidget) SecondaryTapped(_ *fyne.PointEvent) {
+	b.SetMarked(!b.Marked)
+}
+
+// SetMarked marks the tile for exchange or removes the mark
+func (b *TileWidget) SetMarked(marked bool) {
+	b.Marked = marked
+	b.markOverlay.Hidden = !marked
+	b.Refresh()
+}
+
+// UpdateLetter shows the letter currently assigned to a blank tile
+func (b *TileWidget) UpdateLetter() {
+	b.tileText.Text = TileLetter(b.Tile)
+	b.Refresh()
+}
+
 func NewTileWidget(tile *game.Tile, myGame *game.Game) *TileWidget {
 	tileText, scoreText := CreateTileStackComponents(tile)
-	baseTile := canvas.NewImageFromFile("../assets/base_tile.svg")
+	baseTileName := "base_tile.svg"
+	if tile.IsBlank() {
+		baseTileName = "grey_tile.svg"
+	}
+	baseTile := NewAssetImage(baseTileName)
+	markOverlay := canvas.NewRectangle(color.Transparent)
+	markOverlay.StrokeColor = IntToRGBA(game.TWColor)
+	markOverlay.StrokeWidth = 4
+	markOverlay.Hidden = true
 	tileWidget := &TileWidget{
 		Tile: tile,
 		Objects: []fyne.CanvasObject{
 			baseTile,
 			tileText,
 			scoreText,
+			markOverlay,
 		},
 		gameReference: myGame,
+		tileText:      tileText,
+		markOverlay:   markOverlay,
 	}
 	tileWidget.ExtendBaseWidget(tileWidget)
 	return tileWidget
 }
 
+// NewAssetImage creates an image from an image file of the assets. A missing file results in an empty image.
+func NewAssetImage(name string) *canvas.Image {
+	data, err := assets.ReadFile(name)
+	if err != nil {
+		zap.S().Errorf("Failed to load image: %s", err)
+		return &canvas.Image{}
+	}
+	return canvas.NewImageFromResource(fyne.NewStaticResource(name, data))
+}
+
+// TileLetter returns the letter shown on a tile. Blank tiles show the letter assigned to them, if any.
+func TileLetter(tile *game.Tile) string {
+	if tile.IsBlank() {
+		return tile.AssignedLetter
+	}
+	return tile.Letter
+}
+
 func CreateTileStackComponents(tile *game.Tile) (*canvas.Text, *fyne.Container) {
-	tileText := canvas.NewText(tile.Letter, color.Black)
+	tileText := canvas.NewText(TileLetter(tile), color.Black)
 	tileText.Alignment = fyne.TextAlignCenter
 
 	tileText.TextSize = 36
 	tileText.Color = color.Black
+	if tile.IsBlank() {
+		tileText.TextStyle = fyne.TextStyle{Italic: true}
+		tileText.Color = DesaturateColor(color.White, 0.4)
+	}
 
 	letterScore := canvas.NewText(fmt.Sprintf("%d ", tile.LetterScore), color.Black)
 	letterScore.Alignment = fyne.TextAlignTrailing
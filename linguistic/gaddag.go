@@ -0,0 +1,486 @@
+package linguistic
+
+// This represents a GADDAG of the words of a dictionary. Every word is stored once per letter, as the reversed prefix up
+// to that letter, a separator and the remaining suffix. Moves can thus be grown from any tile of the board to the left
+// and then to the right. Letters are the letters of the tiles, so a Spanish "CH" is a single letter.
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"game"
+	"github.com/smhanov/dawg"
+	"go.uber.org/zap"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// separator is the letter index which separates the reversed prefix from the suffix
+const separator = 0
+
+// maxLetters is the maximum number of letters of a tile set, see crossChecks
+const maxLetters = 63
+
+const gaddagMagic = "GADDAG1\n"
+
+var (
+	ErrInvalidGaddag  = errors.New("invalid gaddag data")
+	ErrTooManyLetters = errors.New("tile set has too many letters")
+)
+
+type Gaddag struct {
+	// LexiconID is the ID of the dictionary the GADDAG was built from, see game.Lexicon
+	LexiconID string
+	// letters are the letters of the tile set by index, index 0 is the separator
+	letters []string
+	indices map[string]byte
+	// tiles splits words into letters
+	tiles game.TileSet
+	// Nodes are numbered from the root 0 on, the edges of node n are at firstEdge[n] up to firstEdge[n+1]
+	final       []bool
+	firstEdge   []uint32
+	edgeLetters []byte
+	edgeTargets []uint32
+}
+
+type GaddagActions interface {
+	// IsWord checks if the word is in the GADDAG
+	IsWord(word string) bool
+	// Letters returns the letters of the tile set the GADDAG was built with
+	Letters() []string
+	// NumNodes returns the number of nodes of the GADDAG
+	NumNodes() int
+	Write(writer io.Writer) error
+	Save(path string) error
+}
+
+// NewGaddag builds a GADDAG of the words, which are split into the letters of the tile set. Words which cannot be
+// spelled with the tile set and words of a single letter are left out.
+func NewGaddag(lexiconID string, words []string, tiles game.TileSet) (*Gaddag, error) {
+	gaddag, err := newEmptyGaddag(lexiconID, tiles.Letters())
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(words)*8)
+	for _, word := range words {
+		letters, ok := gaddag.letterIndices(word)
+		if !ok || len(letters) < 2 {
+			continue
+		}
+		for i := 1; i <= len(letters); i++ {
+			path := make([]byte, 0, len(letters)+1)
+			for j := i - 1; j >= 0; j-- {
+				path = append(path, letters[j])
+			}
+			if i < len(letters) {
+				path = append(path, separator)
+				path = append(path, letters[i:]...)
+			}
+			paths = append(paths, string(path))
+		}
+	}
+	sort.Strings(paths)
+	builder := newGaddagBuilder()
+	for i, path := range paths {
+		if i == 0 || path != paths[i-1] {
+			builder.insert([]byte(path))
+		}
+	}
+	builder.finish(gaddag)
+	return gaddag, nil
+}
+
+// NewGaddagFromDictionary builds a GADDAG of all words of the dictionary played with the tiles of the dictionary
+func NewGaddagFromDictionary(dictionary *game.Dictionary) (*Gaddag, error) {
+	words := make([]string, 0, dictionary.WordFinder.NumAdded())
+	dictionary.WordFinder.Enumerate(func(index int, word []rune, final bool) dawg.EnumerationResult {
+		if final {
+			words = append(words, string(word))
+		}
+		return dawg.Continue
+	})
+	return NewGaddag(dictionary.ID(), words, dictionary.TileSet)
+}
+
+// GaddagPath returns the path of the GADDAG file next to a dawg file
+func GaddagPath(dawgPath string) string {
+	return strings.TrimSuffix(dawgPath, ".dawg") + ".gaddag"
+}
+
+// LoadGaddagForDictionary loads the GADDAG of the dictionary from the file at the given path. If the file is missing or
+// was built from another dictionary, the GADDAG is built and saved to the path. Failing to save it is not an error.
+func LoadGaddagForDictionary(dictionary *game.Dictionary, path string) (*Gaddag, error) {
+	gaddag, err := LoadGaddag(path)
+	if err == nil && gaddag.LexiconID == dictionary.ID() {
+		return gaddag, nil
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		zap.S().Warnf("Rebuilding gaddag: %s", err)
+	}
+	gaddag, err = NewGaddagFromDictionary(dictionary)
+	if err != nil {
+		return nil, err
+	}
+	if err := gaddag.Save(path); err != nil {
+		zap.S().Warnf("Failed to save gaddag: %s", err)
+	}
+	return gaddag, nil
+}
+
+func newEmptyGaddag(lexiconID string, letters []string) (*Gaddag, error) {
+	if len(letters) > maxLetters {
+		return nil, fmt.Errorf("%w: %d", ErrTooManyLetters, len(letters))
+	}
+	gaddag := &Gaddag{
+		LexiconID: lexiconID,
+		letters:   append([]string{""}, letters...),
+		indices:   make(map[string]byte, len(letters)),
+		tiles:     game.TileSet{LetterScores: make(map[string]int, len(letters))},
+	}
+	for i, letter := range letters {
+		gaddag.indices[letter] = byte(i + 1)
+		// Only the letters matter for splitting words
+		gaddag.tiles.LetterScores[letter] = 0
+	}
+	return gaddag, nil
+}
+
+// letterIndices splits the word into the indices of its letters
+func (gaddag *Gaddag) letterIndices(word string) ([]byte, bool) {
+	letters, ok := gaddag.tiles.SplitLetters(word)
+	if !ok {
+		return nil, false
+	}
+	indices := make([]byte, len(letters))
+	for i, letter := range letters {
+		indices[i] = gaddag.indices[letter]
+	}
+	return indices, true
+}
+
+// child follows the edge of the node with the letter
+func (gaddag *Gaddag) child(node uint32, letter byte) (uint32, bool) {
+	for i := gaddag.firstEdge[node]; i < gaddag.firstEdge[node+1]; i++ {
+		if gaddag.edgeLetters[i] == letter {
+			return gaddag.edgeTargets[i], true
+		} else if gaddag.edgeLetters[i] > letter {
+			break
+		}
+	}
+	return 0, false
+}
+
+// containsLetters checks if the letter indices form a word by following its reversed letters from the root
+func (gaddag *Gaddag) containsLetters(letters []byte) bool {
+	node := uint32(0)
+	for i := len(letters) - 1; i >= 0; i-- {
+		next, ok := gaddag.child(node, letters[i])
+		if !ok {
+			return false
+		}
+		node = next
+	}
+	return gaddag.final[node]
+}
+
+func (gaddag *Gaddag) IsWord(word string) bool {
+	letters, ok := gaddag.letterIndices(word)
+	return ok && len(letters) > 1 && gaddag.containsLetters(letters)
+}
+
+func (gaddag *Gaddag) Letters() []string {
+	return append([]string{}, gaddag.letters[1:]...)
+}
+
+func (gaddag *Gaddag) NumNodes() int {
+	return len(gaddag.final)
+}
+
+// Write writes the GADDAG in a binary format, see ReadGaddag
+func (gaddag *Gaddag) Write(writer io.Writer) error {
+	buffered := bufio.NewWriter(writer)
+	buffered.WriteString(gaddagMagic)
+	writeString(buffered, gaddag.LexiconID)
+	writeUvarint(buffered, uint64(len(gaddag.letters)-1))
+	for _, letter := range gaddag.letters[1:] {
+		writeString(buffered, letter)
+	}
+	writeUvarint(buffered, uint64(len(gaddag.final)))
+	for node, final := range gaddag.final {
+		if final {
+			buffered.WriteByte(1)
+		} else {
+			buffered.WriteByte(0)
+		}
+		first, last := gaddag.firstEdge[node], gaddag.firstEdge[node+1]
+		writeUvarint(buffered, uint64(last-first))
+		for i := first; i < last; i++ {
+			buffered.WriteByte(gaddag.edgeLetters[i])
+			writeUvarint(buffered, uint64(gaddag.edgeTargets[i]))
+		}
+	}
+	return buffered.Flush()
+}
+
+// Save writes the GADDAG to a file
+func (gaddag *Gaddag) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gaddag.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReadGaddag reads a GADDAG written by Gaddag.Write
+func ReadGaddag(reader io.Reader) (*Gaddag, error) {
+	buffered := bufio.NewReader(reader)
+	magic := make([]byte, len(gaddagMagic))
+	if _, err := io.ReadFull(buffered, magic); err != nil || string(magic) != gaddagMagic {
+		return nil, ErrInvalidGaddag
+	}
+	lexiconID, err := readString(buffered)
+	if err != nil {
+		return nil, err
+	}
+	numLetters, err := readUvarint(buffered, maxLetters)
+	if err != nil {
+		return nil, err
+	}
+	letters := make([]string, numLetters)
+	for i := range letters {
+		if letters[i], err = readString(buffered); err != nil {
+			return nil, err
+		}
+	}
+	gaddag, err := newEmptyGaddag(lexiconID, letters)
+	if err != nil {
+		return nil, err
+	}
+	numNodes, err := readUvarint(buffered, 1<<32-1)
+	if err != nil {
+		return nil, err
+	}
+	// The number of nodes is not trusted for allocating, as a corrupt file could claim billions of them
+	gaddag.final = make([]bool, 0)
+	gaddag.firstEdge = make([]uint32, 0)
+	for node := uint64(0); node < numNodes; node++ {
+		final, err := buffered.ReadByte()
+		if err != nil || final > 1 {
+			return nil, ErrInvalidGaddag
+		}
+		gaddag.final = append(gaddag.final, final == 1)
+		gaddag.firstEdge = append(gaddag.firstEdge, uint32(len(gaddag.edgeLetters)))
+		numEdges, err := readUvarint(buffered, numLetters+1)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < numEdges; i++ {
+			letter, err := buffered.ReadByte()
+			if err != nil || uint64(letter) > numLetters {
+				return nil, ErrInvalidGaddag
+			}
+			target, err := readUvarint(buffered, numNodes-1)
+			if err != nil {
+				return nil, err
+			}
+			gaddag.edgeLetters = append(gaddag.edgeLetters, letter)
+			gaddag.edgeTargets = append(gaddag.edgeTargets, uint32(target))
+		}
+	}
+	if numNodes == 0 {
+		return nil, ErrInvalidGaddag
+	}
+	gaddag.firstEdge = append(gaddag.firstEdge, uint32(len(gaddag.edgeLetters)))
+	return gaddag, nil
+}
+
+// LoadGaddag reads a GADDAG from a file written by Gaddag.Save
+func LoadGaddag(path string) (*Gaddag, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gaddag, err := ReadGaddag(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gaddag '%s': %w", path, err)
+	}
+	return gaddag, nil
+}
+
+func writeUvarint(writer *bufio.Writer, value uint64) {
+	for value >= 0x80 {
+		writer.WriteByte(byte(value) | 0x80)
+		value >>= 7
+	}
+	writer.WriteByte(byte(value))
+}
+
+func writeString(writer *bufio.Writer, value string) {
+	writeUvarint(writer, uint64(len(value)))
+	writer.WriteString(value)
+}
+
+// readUvarint reads an unsigned value and checks that it does not exceed the maximum
+func readUvarint(reader *bufio.Reader, maximum uint64) (uint64, error) {
+	var value uint64
+	for shift := 0; shift < 64; shift += 7 {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, ErrInvalidGaddag
+		}
+		value |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			if value > maximum {
+				return 0, ErrInvalidGaddag
+			}
+			return value, nil
+		}
+	}
+	return 0, ErrInvalidGaddag
+}
+
+func readString(reader *bufio.Reader) (string, error) {
+	length, err := readUvarint(reader, 1<<16)
+	if err != nil {
+		return "", err
+	}
+	value := make([]byte, length)
+	if _, err := io.ReadFull(reader, value); err != nil {
+		return "", ErrInvalidGaddag
+	}
+	return string(value), nil
+}
+
+// gaddagBuilder builds a minimal graph of paths inserted in sorted order. Nodes of the previous path are minimized as
+// soon as the next path branches off, by replacing them with equal nodes built before.
+type gaddagBuilder struct {
+	nodes []builderNode
+	// free holds nodes replaced by equal ones, which can be reused
+	free []uint32
+	// register holds the minimized nodes by their signature
+	register map[string]uint32
+	// unchecked holds the edges of the previous path which are not minimized yet
+	unchecked []uncheckedEdge
+	previous  []byte
+}
+
+type builderNode struct {
+	final bool
+	edges []builderEdge
+}
+
+type builderEdge struct {
+	letter byte
+	node   uint32
+}
+
+type uncheckedEdge struct {
+	parent uint32
+	child  uint32
+}
+
+func newGaddagBuilder() *gaddagBuilder {
+	return &gaddagBuilder{
+		nodes:    []builderNode{{}},
+		register: make(map[string]uint32),
+	}
+}
+
+func (builder *gaddagBuilder) newNode() uint32 {
+	if len(builder.free) > 0 {
+		node := builder.free[len(builder.free)-1]
+		builder.free = builder.free[:len(builder.free)-1]
+		return node
+	}
+	builder.nodes = append(builder.nodes, builderNode{})
+	return uint32(len(builder.nodes) - 1)
+}
+
+func (builder *gaddagBuilder) insert(path []byte) {
+	common := 0
+	for common < len(path) && common < len(builder.previous) && path[common] == builder.previous[common] {
+		common++
+	}
+	builder.minimize(common)
+	node := uint32(0)
+	if len(builder.unchecked) > 0 {
+		node = builder.unchecked[len(builder.unchecked)-1].child
+	}
+	for _, letter := range path[common:] {
+		child := builder.newNode()
+		builder.nodes[node].edges = append(builder.nodes[node].edges, builderEdge{letter: letter, node: child})
+		builder.unchecked = append(builder.unchecked, uncheckedEdge{parent: node, child: child})
+		node = child
+	}
+	builder.nodes[node].final = true
+	builder.previous = path
+}
+
+// minimize replaces the unchecked nodes down to the given depth of the previous path by equal registered nodes
+func (builder *gaddagBuilder) minimize(downTo int) {
+	for i := len(builder.unchecked) - 1; i >= downTo; i-- {
+		edge := builder.unchecked[i]
+		signature := builder.signature(edge.child)
+		if node, ok := builder.register[signature]; ok {
+			// The edge to the child is always the last edge of the parent, as paths are inserted in sorted order
+			edges := builder.nodes[edge.parent].edges
+			edges[len(edges)-1].node = node
+			builder.nodes[edge.child] = builderNode{edges: builder.nodes[edge.child].edges[:0]}
+			builder.free = append(builder.free, edge.child)
+		} else {
+			builder.register[signature] = edge.child
+		}
+	}
+	builder.unchecked = builder.unchecked[:downTo]
+}
+
+func (builder *gaddagBuilder) signature(node uint32) string {
+	var signature strings.Builder
+	if builder.nodes[node].final {
+		signature.WriteByte(1)
+	} else {
+		signature.WriteByte(0)
+	}
+	for _, edge := range builder.nodes[node].edges {
+		signature.WriteByte(edge.letter)
+		signature.WriteByte(byte(edge.node >> 24))
+		signature.WriteByte(byte(edge.node >> 16))
+		signature.WriteByte(byte(edge.node >> 8))
+		signature.WriteByte(byte(edge.node))
+	}
+	return signature.String()
+}
+
+// finish minimizes the last path and stores the nodes reachable from the root in the GADDAG
+func (builder *gaddagBuilder) finish(gaddag *Gaddag) {
+	builder.minimize(0)
+	numbers := map[uint32]uint32{0: 0}
+	order := []uint32{0}
+	for i := 0; i < len(order); i++ {
+		for _, edge := range builder.nodes[order[i]].edges {
+			if _, ok := numbers[edge.node]; !ok {
+				numbers[edge.node] = uint32(len(order))
+				order = append(order, edge.node)
+			}
+		}
+	}
+	gaddag.final = make([]bool, len(order))
+	gaddag.firstEdge = make([]uint32, len(order)+1)
+	for i, node := range order {
+		gaddag.final[i] = builder.nodes[node].final
+		gaddag.firstEdge[i] = uint32(len(gaddag.edgeLetters))
+		for _, edge := range builder.nodes[node].edges {
+			gaddag.edgeLetters = append(gaddag.edgeLetters, edge.letter)
+			gaddag.edgeTargets = append(gaddag.edgeTargets, numbers[edge.node])
+		}
+	}
+	gaddag.firstEdge[len(order)] = uint32(len(gaddag.edgeLetters))
+}
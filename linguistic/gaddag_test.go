@@ -1,7 +1,14 @@
 package linguistic
 
 import (
+	"bufio"
+	"bytes"
+	"game"
 	"github.com/adject1/macondo/gaddagmaker"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +21,120 @@ func TestGaddag(t *testing.T) {
 	})
 
 }
+
+func TestNewGaddag(t *testing.T) {
+	words := []string{"care", "cares", "car", "scare", "scar", "ace", "aces", "a", "x1"}
+
+	t.Run("Words are in the gaddag", func(t *testing.T) {
+		gaddag, err := NewGaddag("test", words, game.EnglishTileSet())
+		assert.NoError(t, err)
+		for _, word := range []string{"care", "CARES", "car", "scare", "scar", "ace", "aces"} {
+			assert.True(t, gaddag.IsWord(word), word)
+		}
+		for _, word := range []string{"ca", "cars", "scares", "a", "x1", "", "acer"} {
+			assert.False(t, gaddag.IsWord(word), word)
+		}
+	})
+
+	t.Run("Every word can be followed from each of its letters", func(t *testing.T) {
+		gaddag, _ := NewGaddag("test", words, game.EnglishTileSet())
+		letters, _ := gaddag.letterIndices("scare")
+		for i := 1; i <= len(letters); i++ {
+			node := uint32(0)
+			path := make([]byte, 0)
+			for j := i - 1; j >= 0; j-- {
+				path = append(path, letters[j])
+			}
+			if i < len(letters) {
+				path = append(append(path, separator), letters[i:]...)
+			}
+			ok := true
+			for _, letter := range path {
+				if node, ok = gaddag.child(node, letter); !ok {
+					break
+				}
+			}
+			assert.True(t, ok && gaddag.final[node], i)
+		}
+	})
+
+	t.Run("Equal suffixes share nodes", func(t *testing.T) {
+		gaddag, _ := NewGaddag("test", words, game.EnglishTileSet())
+		// A trie of the same paths would need a node per letter of every path
+		paths := 0
+		for _, word := range []string{"care", "cares", "car", "scare", "scar", "ace", "aces"} {
+			paths += len(word) * (len(word) + 1) / 2
+		}
+		assert.Less(t, gaddag.NumNodes(), paths/2)
+	})
+
+	t.Run("Multi-rune tiles are single letters", func(t *testing.T) {
+		gaddag, err := NewGaddag("test", []string{"chorro", "año", "kilo"}, game.SpanishTileSet())
+		assert.NoError(t, err)
+		assert.True(t, gaddag.IsWord("chorro"))
+		assert.True(t, gaddag.IsWord("AÑO"))
+		// There is no K in Spanish
+		assert.False(t, gaddag.IsWord("kilo"))
+		assert.Contains(t, gaddag.Letters(), "CH")
+	})
+
+	t.Run("Gaddags are written and read", func(t *testing.T) {
+		gaddag, _ := NewGaddag("test@0123", words, game.EnglishTileSet())
+		var buffer bytes.Buffer
+		assert.NoError(t, gaddag.Write(&buffer))
+		data := buffer.Bytes()
+		read, err := ReadGaddag(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, gaddag, read)
+
+		_, err = ReadGaddag(bytes.NewReader(data[:len(data)-3]))
+		assert.ErrorIs(t, err, ErrInvalidGaddag)
+		_, err = ReadGaddag(strings.NewReader("no gaddag"))
+		assert.ErrorIs(t, err, ErrInvalidGaddag)
+
+		// A header claiming the maximum number of nodes without any nodes following is rejected
+		var header bytes.Buffer
+		writer := bufio.NewWriter(&header)
+		writer.WriteString(gaddagMagic)
+		writeString(writer, gaddag.LexiconID)
+		writeUvarint(writer, uint64(len(gaddag.Letters())))
+		for _, letter := range gaddag.Letters() {
+			writeString(writer, letter)
+		}
+		writeUvarint(writer, 1<<32-1)
+		assert.NoError(t, writer.Flush())
+		_, err = ReadGaddag(&header)
+		assert.ErrorIs(t, err, ErrInvalidGaddag)
+	})
+
+	t.Run("Gaddags are cached next to the dictionary", func(t *testing.T) {
+		dictionary, err := game.ReadDictionaryFromCSV(strings.NewReader("care\ncar\nscare\n"), game.EnglishTileSet())
+		assert.NoError(t, err)
+		dictionary.Name = "test"
+		path := GaddagPath(filepath.Join(t.TempDir(), "test.dawg"))
+		assert.Equal(t, "test.gaddag", filepath.Base(path))
+
+		gaddag, err := LoadGaddagForDictionary(dictionary, path)
+		assert.NoError(t, err)
+		assert.Equal(t, dictionary.ID(), gaddag.LexiconID)
+		assert.FileExists(t, path)
+		cached, err := LoadGaddag(path)
+		assert.NoError(t, err)
+		assert.Equal(t, gaddag, cached)
+
+		// A gaddag of another dictionary is rebuilt
+		other, err := game.ReadDictionaryFromCSV(strings.NewReader("scar\n"), game.EnglishTileSet())
+		assert.NoError(t, err)
+		other.Name = "test"
+		gaddag, err = LoadGaddagForDictionary(other, path)
+		assert.NoError(t, err)
+		assert.True(t, gaddag.IsWord("scar"))
+		assert.False(t, gaddag.IsWord("care"))
+
+		// A broken file is rebuilt, too
+		assert.NoError(t, os.WriteFile(path, []byte("broken"), 0644))
+		gaddag, err = LoadGaddagForDictionary(other, path)
+		assert.NoError(t, err)
+		assert.True(t, gaddag.IsWord("scar"))
+	})
+}
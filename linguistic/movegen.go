@@ -0,0 +1,295 @@
+package linguistic
+
+// This represents the move generator, which finds all legal moves of a rack with the GADDAG. Moves are grown from
+// anchors, the empty fields next to tiles on the board, first to the left and then to the right of the anchor, see
+// Gordon, "A faster Scrabble move generation algorithm". Fields the move fills besides the line it is placed on are
+// restricted by cross checks, the letters which form a word with the tiles above and below.
+
+import (
+	"fmt"
+	"game"
+	"sort"
+	"strings"
+)
+
+// MoveGenerator implements game.MoveGenerator with a GADDAG
+type MoveGenerator struct {
+	gaddag *Gaddag
+}
+
+// NewMoveGenerator creates a move generator for the words of the GADDAG. The board has to be played with the tile set
+// the GADDAG was built with.
+func NewMoveGenerator(gaddag *Gaddag) *MoveGenerator {
+	return &MoveGenerator{gaddag: gaddag}
+}
+
+func (generator *MoveGenerator) GenerateMoves(board *game.Board, rack []game.Tile) []game.Placement {
+	search := &moveSearch{
+		gaddag:     generator.gaddag,
+		board:      board,
+		emptyBoard: board.IsEmpty(),
+		rack:       rack,
+		counts:     make([]int, len(generator.gaddag.letters)),
+		seen:       make(map[string]bool),
+		placements: make([]game.Placement, 0),
+	}
+	for _, tile := range rack {
+		if tile.IsBlank() {
+			search.blanks++
+		} else if letter, ok := generator.gaddag.indices[tile.Letter]; ok {
+			search.counts[letter]++
+		}
+	}
+	for _, direction := range []game.Direction{game.Horizontal, game.Vertical} {
+		search.direction = direction
+		for line := 0; line < search.lines(); line++ {
+			search.searchLine(line)
+		}
+	}
+	sort.SliceStable(search.placements, func(a, b int) bool {
+		return search.placements[a].Score.Total > search.placements[b].Score.Total
+	})
+	return search.placements
+}
+
+// placedTile is a rack tile placed during the search at a position of the current line
+type placedTile struct {
+	position int
+	letter   byte
+	blank    bool
+}
+
+// moveSearch holds the state of a search for the moves of a rack
+type moveSearch struct {
+	gaddag *Gaddag
+	board  *game.Board
+	// emptyBoard is set for the first move, which is anchored on the center star
+	emptyBoard bool
+	rack       []game.Tile
+	// counts are the number of rack tiles by letter, blanks are counted separately
+	counts []int
+	blanks int
+	// direction and line are the line moves are searched on, positions are along the line
+	direction game.Direction
+	line      int
+	anchor    int
+	// crossChecks are the letters allowed on the fields of the line as bit sets
+	crossChecks []uint64
+	placed      []placedTile
+	// seen holds the placements found so far, a single tile is found in both directions
+	seen       map[string]bool
+	placements []game.Placement
+}
+
+// lines returns the number of lines in the search direction
+func (search *moveSearch) lines() int {
+	if search.direction == game.Horizontal {
+		return len(search.board.Fields[0])
+	}
+	return len(search.board.Fields)
+}
+
+// length returns the number of fields of a line
+func (search *moveSearch) length() int {
+	if search.direction == game.Horizontal {
+		return len(search.board.Fields)
+	}
+	return len(search.board.Fields[0])
+}
+
+// coordinates returns the board coordinates of a position on the current line
+func (search *moveSearch) coordinates(position int) (int, int) {
+	if search.direction == game.Horizontal {
+		return position, search.line
+	}
+	return search.line, position
+}
+
+// tileAt returns the tile on the board at a position of the current line, nil if the field is empty or off the board
+func (search *moveSearch) tileAt(position int) *game.Tile {
+	field, ok := search.board.GetField(search.coordinates(position))
+	if !ok {
+		return nil
+	}
+	return field.Tile
+}
+
+func (search *moveSearch) isAnchor(position int) bool {
+	x, y := search.coordinates(position)
+	if !search.board.IsFieldEmpty(x, y) {
+		return false
+	}
+	if search.emptyBoard {
+		field, _ := search.board.GetField(x, y)
+		return field.Type == game.CS
+	}
+	for _, neighbour := range [][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+		if field, ok := search.board.GetField(neighbour[0], neighbour[1]); ok && field.Tile != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (search *moveSearch) searchLine(line int) {
+	search.line = line
+	search.crossChecks = make([]uint64, search.length())
+	for position := range search.crossChecks {
+		search.crossChecks[position] = search.crossCheck(position)
+	}
+	for position := 0; position < search.length(); position++ {
+		if search.isAnchor(position) {
+			search.anchor = position
+			search.gen(position, 0, true)
+		}
+	}
+}
+
+// crossCheck returns the letters which can be placed on a field of the current line, as they form a word with the
+// tiles in the perpendicular direction
+func (search *moveSearch) crossCheck(position int) uint64 {
+	all := uint64(1)<<len(search.gaddag.letters) - 2
+	x, y := search.coordinates(position)
+	if !search.board.IsFieldEmpty(x, y) {
+		return 0
+	}
+	dx, dy := search.direction.Perpendicular().Step()
+	before := search.perpendicularLetters(x, y, -dx, -dy)
+	after := search.perpendicularLetters(x, y, dx, dy)
+	if before == nil || after == nil {
+		return 0
+	}
+	if len(before) == 0 && len(after) == 0 {
+		return all
+	}
+	// before is collected from the field outwards
+	letters := make([]byte, 0, len(before)+1+len(after))
+	for i := len(before) - 1; i >= 0; i-- {
+		letters = append(letters, before[i])
+	}
+	letters = append(letters, 0)
+	letters = append(letters, after...)
+	checks := uint64(0)
+	for letter := 1; letter < len(search.gaddag.letters); letter++ {
+		letters[len(before)] = byte(letter)
+		if search.gaddag.containsLetters(letters) {
+			checks |= 1 << letter
+		}
+	}
+	return checks
+}
+
+// perpendicularLetters collects the letters of the tiles next to a field in one direction. It returns nil if a tile is
+// not a letter of the GADDAG.
+func (search *moveSearch) perpendicularLetters(x int, y int, dx int, dy int) []byte {
+	letters := make([]byte, 0)
+	for x, y = x+dx, y+dy; !search.board.IsFieldEmpty(x, y); x, y = x+dx, y+dy {
+		field, ok := search.board.GetField(x, y)
+		if !ok {
+			break
+		}
+		letter, ok := search.gaddag.indices[field.Tile.PlayedLetter()]
+		if !ok {
+			return nil
+		}
+		letters = append(letters, letter)
+	}
+	return letters
+}
+
+// gen fills the field at the position with a letter following the node. Left of the anchor the move grows leftwards,
+// right of it rightwards.
+func (search *moveSearch) gen(position int, node uint32, leftwards bool) {
+	if tile := search.tileAt(position); tile != nil {
+		letter, ok := search.gaddag.indices[tile.PlayedLetter()]
+		if !ok {
+			return
+		}
+		if next, ok := search.gaddag.child(node, letter); ok {
+			search.goOn(position, next, leftwards)
+		}
+		return
+	}
+	// A move covering another anchor left of this anchor is found from that anchor
+	if leftwards && position != search.anchor && search.isAnchor(position) {
+		return
+	}
+	checks := search.crossChecks[position]
+	for i := search.gaddag.firstEdge[node]; i < search.gaddag.firstEdge[node+1]; i++ {
+		letter, next := search.gaddag.edgeLetters[i], search.gaddag.edgeTargets[i]
+		if letter == separator || checks&(1<<letter) == 0 {
+			continue
+		}
+		if search.counts[letter] > 0 {
+			search.counts[letter]--
+			search.placed = append(search.placed, placedTile{position: position, letter: letter})
+			search.goOn(position, next, leftwards)
+			search.placed = search.placed[:len(search.placed)-1]
+			search.counts[letter]++
+		}
+		if search.blanks > 0 {
+			search.blanks--
+			search.placed = append(search.placed, placedTile{position: position, letter: letter, blank: true})
+			search.goOn(position, next, leftwards)
+			search.placed = search.placed[:len(search.placed)-1]
+			search.blanks++
+		}
+	}
+}
+
+// goOn continues the move after the field at the position was filled and records it if it forms a word
+func (search *moveSearch) goOn(position int, node uint32, leftwards bool) {
+	if leftwards {
+		leftEmpty := search.tileAt(position-1) == nil
+		if search.gaddag.final[node] && leftEmpty && search.tileAt(search.anchor+1) == nil {
+			search.record()
+		}
+		if position > 0 {
+			search.gen(position-1, node, true)
+		}
+		if separatorNode, ok := search.gaddag.child(node, separator); ok && leftEmpty && search.anchor+1 < search.length() {
+			search.gen(search.anchor+1, separatorNode, false)
+		}
+		return
+	}
+	if search.gaddag.final[node] && search.tileAt(position+1) == nil {
+		search.record()
+	}
+	if position+1 < search.length() {
+		search.gen(position+1, node, false)
+	}
+}
+
+// record adds the placed tiles as a placement unless it was found before. Rack tiles are assigned to the placed
+// letters, so the moves can be played with the tiles of the rack.
+func (search *moveSearch) record() {
+	placed := append([]placedTile{}, search.placed...)
+	sort.Slice(placed, func(a, b int) bool {
+		return placed[a].position < placed[b].position
+	})
+	var key strings.Builder
+	move := make([]game.Move, 0, len(placed))
+	used := make([]bool, len(search.rack))
+	for _, tile := range placed {
+		x, y := search.coordinates(tile.position)
+		letter := search.gaddag.letters[tile.letter]
+		fmt.Fprintf(&key, "%d,%d,%s,%t;", x, y, letter, tile.blank)
+		for i := range search.rack {
+			if used[i] || search.rack[i].IsBlank() != tile.blank || (!tile.blank && search.rack[i].Letter != letter) {
+				continue
+			}
+			used[i] = true
+			rackTile := search.rack[i]
+			if tile.blank {
+				rackTile.AssignedLetter = letter
+			}
+			move = append(move, game.Move{X: x, Y: y, Tile: &rackTile})
+			break
+		}
+	}
+	if search.seen[key.String()] {
+		return
+	}
+	search.seen[key.String()] = true
+	search.placements = append(search.placements, game.Placement{Move: move, Score: search.board.ScoreMove(move)})
+}
@@ -0,0 +1,160 @@
+package linguistic
+
+import (
+	"fmt"
+	"game"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+var testWords = []string{"care", "cares", "car", "cars", "scare", "scar", "race", "races", "acre", "arc", "arcs", "ace",
+	"aces", "sea", "ear", "ears", "era", "eras", "as", "re", "ae", "ra"}
+
+// newTestRack creates tiles with unique IDs for the letters
+func newTestRack(letters ...string) []game.Tile {
+	rack := make([]game.Tile, len(letters))
+	for i, letter := range letters {
+		rack[i] = game.Tile{ID: 1000 + i, Letter: letter, LetterScore: game.LetterScores[letter]}
+	}
+	return rack
+}
+
+// newTestGame creates a game validating against the test words with the word placed on the board
+func newTestGame(t *testing.T, word string, x int, y int, direction game.Direction) *game.Game {
+	myGame, err := game.NewGame(game.WithLexicon(game.NewWordList("test", testWords)), game.WithSeed(1))
+	assert.NoError(t, err)
+	dx, dy := direction.Step()
+	for i, letter := range word {
+		tile := game.NewTile(string(letter), game.LetterScores[string(letter)])
+		tile.ID = 2000 + i
+		myGame.Board.PlaceTile(tile, x+i*dx, y+i*dy)
+	}
+	return myGame
+}
+
+// placementKey identifies a placement by the positions and letters of its tiles
+func placementKey(move []game.Move) string {
+	keys := make([]string, len(move))
+	for i, m := range move {
+		keys[i] = fmt.Sprintf("%d,%d,%s", m.X, m.Y, m.Tile.PlayedLetter())
+	}
+	sort.Strings(keys)
+	return fmt.Sprint(keys)
+}
+
+// bruteForceMoves checks every placement of up to all rack tiles in a row or column
+func bruteForceMoves(myGame *game.Game, player *game.Player) map[string]int {
+	moves := make(map[string]int)
+	columns, rows := len(myGame.Board.Fields), len(myGame.Board.Fields[0])
+	var place func(move []game.Move, used []bool, x int, y int, dx int, dy int)
+	place = func(move []game.Move, used []bool, x int, y int, dx int, dy int) {
+		for !myGame.Board.IsFieldEmpty(x, y) {
+			if _, ok := myGame.Board.GetField(x, y); !ok {
+				return
+			}
+			x, y = x+dx, y+dy
+		}
+		for i := range player.Tiles {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			tile := player.Tiles[i]
+			next := append(append([]game.Move{}, move...), game.Move{X: x, Y: y, Tile: &tile})
+			if myGame.CheckMove(player, next).IsValid {
+				moves[placementKey(next)] = myGame.Board.ScoreMove(next).Total
+			}
+			place(next, used, x+dx, y+dy, dx, dy)
+			used[i] = false
+		}
+	}
+	for x := 0; x < columns; x++ {
+		for y := 0; y < rows; y++ {
+			if myGame.Board.IsFieldEmpty(x, y) {
+				place(nil, make([]bool, len(player.Tiles)), x, y, 1, 0)
+				place(nil, make([]bool, len(player.Tiles)), x, y, 0, 1)
+			}
+		}
+	}
+	return moves
+}
+
+func TestMoveGenerator(t *testing.T) {
+	gaddag, err := NewGaddag("test", testWords, game.EnglishTileSet())
+	assert.NoError(t, err)
+	generator := NewMoveGenerator(gaddag)
+
+	t.Run("All legal moves are generated", func(t *testing.T) {
+		for _, test := range []struct {
+			name      string
+			word      string
+			direction game.Direction
+			rack      []string
+		}{
+			{"Empty board", "", game.Horizontal, []string{"C", "A", "R", "E"}},
+			{"Horizontal word", "CAR", game.Horizontal, []string{"S", "E", "A", "R"}},
+			{"Vertical word", "RACE", game.Vertical, []string{"S", "C", "A", "E"}},
+		} {
+			myGame := newTestGame(t, test.word, 6, 7, test.direction)
+			player := &game.Player{Name: "Player 1", Tiles: newTestRack(test.rack...)}
+			expected := bruteForceMoves(myGame, player)
+			generated := make(map[string]int)
+			for _, placement := range generator.GenerateMoves(myGame.Board, player.Tiles) {
+				key := placementKey(placement.Move)
+				assert.NotContains(t, generated, key, test.name)
+				assert.True(t, myGame.CheckMove(player, placement.Move).IsValid, test.name+" "+key)
+				generated[key] = placement.Score.Total
+			}
+			assert.NotEmpty(t, expected, test.name)
+			assert.Equal(t, expected, generated, test.name)
+		}
+	})
+
+	t.Run("Moves are sorted by score", func(t *testing.T) {
+		myGame := newTestGame(t, "CAR", 6, 7, game.Horizontal)
+		placements := generator.GenerateMoves(myGame.Board, newTestRack("S", "E", "A", "R"))
+		assert.NotEmpty(t, placements)
+		for i := 1; i < len(placements); i++ {
+			assert.GreaterOrEqual(t, placements[i-1].Score.Total, placements[i].Score.Total)
+		}
+	})
+
+	t.Run("Blanks are assigned letters", func(t *testing.T) {
+		myGame := newTestGame(t, "CAR", 6, 7, game.Horizontal)
+		player := &game.Player{Name: "Player 1", Tiles: newTestRack("S", game.BlankLetter)}
+		placements := generator.GenerateMoves(myGame.Board, player.Tiles)
+		found := false
+		for _, placement := range placements {
+			assert.True(t, myGame.CheckMove(player, placement.Move).IsValid, placementKey(placement.Move))
+			for _, move := range placement.Move {
+				if move.Tile.IsBlank() {
+					assert.NotEmpty(t, move.Tile.AssignedLetter)
+					assert.Zero(t, move.Tile.LetterScore)
+				}
+			}
+			// S and a blank E around CAR form "SCARE"
+			if placementKey(placement.Move) == "[5,7,S 9,7,E]" {
+				found = true
+				assert.Equal(t, 1+3+1+1, placement.Score.Total)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("Multi-rune tiles are placed as one letter", func(t *testing.T) {
+		spanish := game.SpanishTileSet()
+		gaddag, err := NewGaddag("test", []string{"chorro", "corro"}, spanish)
+		assert.NoError(t, err)
+		rules := game.OfficialRules()
+		rules.TileSet = spanish
+		board := game.NewBoardWithRuleSet(rules)
+		rack := []game.Tile{{ID: 1, Letter: "CH", LetterScore: 5}, {ID: 2, Letter: "O", LetterScore: 1},
+			{ID: 3, Letter: "RR", LetterScore: 8}, {ID: 4, Letter: "O", LetterScore: 1}, {ID: 5, Letter: "C", LetterScore: 2}}
+		placements := NewMoveGenerator(gaddag).GenerateMoves(board, rack)
+		assert.NotEmpty(t, placements)
+		assert.Len(t, placements[0].Move, 4)
+		assert.Equal(t, "CH", placements[0].Move[0].Tile.Letter)
+		assert.Equal(t, (5+1+8+1)*2, placements[0].Score.Total)
+	})
+}
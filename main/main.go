@@ -1,16 +1,36 @@
 package main
 
 import (
+	"assets"
+	"bot"
+	"config"
+	"errors"
+	"flag"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 	"game"
 	"go.uber.org/zap"
 	"gui"
+	"io/fs"
+	"linguistic"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 )
 
+// endgameTimeLimit is the time the end game solver may take
+const endgameTimeLimit = 10 * time.Second
+
+// hintCount is the number of moves offered as hints
+const hintCount = 10
+
 func main() {
 
 	logger, _ := zap.NewDevelopment()
@@ -18,8 +38,79 @@ func main() {
 
 	undo := zap.ReplaceGlobals(logger)
 	defer undo()
-	//config.WriteConfig(config.NewConfig())
-	windowSize := fyne.NewSize(gui.CellWidth*16+100, gui.CellHeight*16+146)
+	configPath := flag.String("config", "config.json", "Config file with the board layout, letter scores, tile distribution and theme. "+
+		"The defaults are used if it is not given and config.json is missing.")
+	ruleSets := map[string]func() *game.RuleSet{
+		"official": game.OfficialRules,
+		"wwf":      game.WordsWithFriendsRules,
+		"super":    game.SuperScrabbleRules,
+	}
+	challengeRules := map[string]game.ChallengeRule{
+		"void":   game.VoidChallenge,
+		"single": game.SingleChallenge,
+		"double": game.DoubleChallenge,
+	}
+	ruleSetName := flag.String("rules", "config", "Rule variant to play: config, official, wwf or super")
+	challengeRuleName := flag.String("challenge", "void", "Challenge rule: void, single or double")
+	challengePenalty := flag.Int("penalty", 0, "Points lost for challenging a valid move under the single challenge rule")
+	languageCode := flag.String("lang", "", "Language of the dictionary and the tiles: en, fr, es or de. "+
+		"Replaces the tiles of the rule variant, which is played in English otherwise.")
+	lexiconCodes := flag.String("lexicon", "", "Comma separated languages whose dictionaries are combined to validate "+
+		"words, e.g. en,fr. Defaults to the language of the game.")
+	addendumPath := flag.String("addendum", "", "File with words allowed by house rules in addition to the dictionary, "+
+		"one word per line")
+	assetDirectory := flag.String("assets", "", "Directory with dictionaries and images overriding the bundled ones")
+	botLevel := flag.String("bot", "", "Play against a computer opponent: beginner, intermediate, expert or "+
+		"simulation, which simulates the best moves a few turns ahead")
+	flag.Parse()
+	assets.SetOverrideDirectory(*assetDirectory)
+	configGiven := false
+	flag.Visit(func(f *flag.Flag) { configGiven = configGiven || f.Name == "config" })
+	myConfig, err := config.ReadConfigFile(*configPath)
+	if errors.Is(err, fs.ErrNotExist) && !configGiven {
+		// Without a config file in the working directory, the game starts with the built-in defaults
+		zap.S().Infof("No config file '%s', using the default config", *configPath)
+		myConfig, err = config.NewConfig(), nil
+	}
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+	ruleSets["config"] = func() *game.RuleSet {
+		rules, err := game.RuleSetFromConfig(myConfig)
+		if err != nil {
+			zap.S().Fatal(err)
+		}
+		return rules
+	}
+	ruleSet, ok := ruleSets[*ruleSetName]
+	if !ok {
+		zap.S().Fatalf("Unknown rule variant '%s'", *ruleSetName)
+	}
+	challengeRule, ok := challengeRules[*challengeRuleName]
+	if !ok {
+		zap.S().Fatalf("Unknown challenge rule '%s'", *challengeRuleName)
+	}
+	rules := ruleSet()
+	rules.Challenge = challengeRule
+	rules.ChallengePenalty = *challengePenalty
+	gameOptions := []game.GameOption{game.WithRuleSet(rules)}
+	if *languageCode != "" {
+		language, err := game.LanguageByCode(*languageCode)
+		if err != nil {
+			zap.S().Fatal(err)
+		}
+		gameOptions = append(gameOptions, game.WithLanguage(language))
+	}
+	if *lexiconCodes != "" || *addendumPath != "" {
+		if *lexiconCodes == "" {
+			*lexiconCodes = *languageCode
+		}
+		lexicon, err := loadLexicon(*lexiconCodes, *addendumPath)
+		if err != nil {
+			zap.S().Fatal(err)
+		}
+		gameOptions = append(gameOptions, game.WithLexicon(lexicon))
+	}
 
 	myApp := app.New()
 
@@ -45,30 +136,205 @@ func main() {
 
 	myWindow := myApp.NewWindow("Lets Play Scrabble!")
 
-	myGame := game.NewGame()
+	myGame, err := game.NewGame(gameOptions...)
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+	zap.S().Infof("Validating words against lexicon %s", myGame.LexiconID)
+	boardSize := float32(len(myGame.Board.Fields))
+	windowSize := fyne.NewSize(gui.CellWidth*(boardSize+1)+100, gui.CellHeight*(boardSize+1)+146)
 
-	myGame.Players = append(myGame.Players, *game.NewPlayer("Player 1"))
-
-	myGame.PullNewTilesFromBag(myGame.CurrentPlayer)
+	player := game.NewPlayerWithRandomName()
+	if err := myGame.Execute(game.AddPlayerCommand{Player: player}); err != nil {
+		zap.S().Fatal(err)
+	}
+	// The move generator is only loaded once it is needed, as building its GADDAG takes a few seconds. Its GADDAG is
+	// built from the dictionary of the language, so the moves are restricted to the words of the lexicon of the game.
+	moveGenerator := sync.OnceValues(func() (game.MoveGenerator, error) {
+		generator, err := loadMoveGenerator(*languageCode, *assetDirectory)
+		if err != nil {
+			return nil, err
+		}
+		return game.NewLexiconGenerator(generator, myGame.Lexicon), nil
+	})
+	if *botLevel != "" {
+		generator, err := moveGenerator()
+		if err != nil {
+			zap.S().Fatal(err)
+		}
+		var strategy game.Strategy
+		source := rand.NewSource(time.Now().UnixNano())
+		if *botLevel == "simulation" {
+			strategy = bot.NewSimulationStrategy(generator, source)
+		} else {
+			level, err := bot.ParseLevel(*botLevel)
+			if err != nil {
+				zap.S().Fatal(err)
+			}
+			strategy = bot.NewStaticStrategy(generator, level, source)
+		}
+		if err := myGame.Execute(game.AddPlayerCommand{Player: game.NewBotPlayer("Bot", strategy)}); err != nil {
+			zap.S().Fatal(err)
+		}
+		stopBots := myGame.RunBots()
+		defer stopBots()
+	}
+	if err := myGame.Execute(game.StartCommand{}); err != nil {
+		zap.S().Fatal(err)
+	}
 
-	mainGrid := gui.NewBoardWidget(myGame)
+	mainGrid := gui.NewBoardWidget(myGame, player, myConfig.Theme)
+	mainGrid.OnBlankPlaced = func(tile *game.Tile, assigned func()) {
+		letterSelect := widget.NewSelect(myGame.Rules.Letters(), nil)
+		formItems := []*widget.FormItem{widget.NewFormItem("Buchstabe", letterSelect)}
+		dialog.ShowForm("Joker", "OK", "Abbrechen", formItems, func(confirmed bool) {
+			if confirmed && tile.AssignLetter(letterSelect.Selected, &myGame.Rules.TileSet) {
+				assigned()
+			}
+		}, myWindow)
+	}
 
 	playButton := widget.NewButton("Zug spielen!", func() {
-		scoredPoints := myGame.PlayTemporaryMoves(myGame.CurrentPlayer)
-		if scoredPoints > 0 {
-			zap.S().Info(fmt.Sprintf("Player '%s' scored %d points", myGame.CurrentPlayer.Name, scoredPoints))
+		command := &game.PlayCommand{Player: mainGrid.Player}
+		if err := myGame.Execute(command); errors.Is(err, game.ErrInvalidMove) {
+			dialog.ShowInformation("Ungültiger Zug", command.Result.String(), myWindow)
+			return
+		} else if err != nil {
+			dialog.ShowError(err, myWindow)
+			return
 		}
+		zap.S().Info(fmt.Sprintf("Player '%s' scored %s", command.Player.Name, command.Score))
 	})
 
 	passButton := widget.NewButton("Passen!", func() {
-		zap.S().Info("Pass pressed")
+		if err := myGame.Execute(game.PassCommand{Player: mainGrid.Player}); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	})
+
+	// Tiles are marked for exchange by right-clicking them on the rack
+	exchangeButton := widget.NewButton("Tauschen!", func() {
+		markedTiles := mainGrid.MarkedTiles()
+		tiles := make([]game.Tile, len(markedTiles))
+		for i, tile := range markedTiles {
+			tiles[i] = *tile
+		}
+		if err := myGame.Execute(game.ExchangeCommand{Player: mainGrid.Player, Tiles: tiles}); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	})
+
+	acceptButton := widget.NewButton("Annehmen", func() {
+		if err := myGame.Execute(game.AcceptCommand{Player: mainGrid.Player}); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	})
+
+	challengeButton := widget.NewButton("Anfechten!", func() {
+		command := &game.ChallengeCommand{Challenger: mainGrid.Player}
+		if err := myGame.Execute(command); err != nil {
+			dialog.ShowError(err, myWindow)
+			return
+		}
+		message := "Der Zug ist gültig."
+		if command.Result.Phony {
+			message = "Der Zug wurde zurückgenommen."
+		} else if command.Result.LostTurn {
+			message += " Du setzt eine Runde aus."
+		} else if command.Result.Penalty > 0 {
+			message += fmt.Sprintf(" Du verlierst %d Punkte.", command.Result.Penalty)
+		}
+		dialog.ShowInformation("Anfechtung", message, myWindow)
+	})
+
+	undoButton := widget.NewButton("Zurück", func() {
+		if err := myGame.Execute(game.UndoCommand{}); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	})
+
+	redoButton := widget.NewButton("Wiederholen", func() {
+		if err := myGame.Execute(game.RedoCommand{}); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	})
+
+	// The end game is solved on a snapshot in the background, so the window stays responsive
+	solveButton := widget.NewButton("Endspiel lösen", func() {
+		go func() {
+			generator, err := moveGenerator()
+			if err != nil {
+				dialog.ShowError(err, myWindow)
+				return
+			}
+			var endgame *game.Endgame
+			myGame.View(func(myGame *game.Game) {
+				endgame, err = myGame.Endgame()
+			})
+			if err != nil {
+				dialog.ShowError(err, myWindow)
+				return
+			}
+			solution := endgame.Solve(generator, endgameTimeLimit)
+			dialog.ShowInformation("Endspiel", gui.EndgameText(solution), myWindow)
+		}()
 	})
 
-	remainingTilesLabel := widget.NewLabel(fmt.Sprintf("Verbleibende Steine: %d", len(myGame.Bag.Tiles)))
-	yourPointsLabel := widget.NewLabel(fmt.Sprintf("Deine Punkte: %d", 0))
-	yourNameLabel := widget.NewLabel(fmt.Sprintf("Dein Name: %s", myGame.CurrentPlayer.Name))
+	// The hints are searched on a snapshot in the background like the end game, a chosen hint is placed on the board
+	// to be played unless the game changed in the meantime
+	hintButton := widget.NewButton("Tipp", func() {
+		go func() {
+			generator, err := moveGenerator()
+			if err != nil {
+				dialog.ShowError(err, myWindow)
+				return
+			}
+			var findHints func() []game.Hint
+			myGame.View(func(myGame *game.Game) {
+				if err = myGame.CheckTurn(mainGrid.Player); err == nil {
+					findHints, err = myGame.Hints(generator, hintCount)
+				}
+			})
+			if err != nil {
+				dialog.ShowError(err, myWindow)
+				return
+			}
+			hints := findHints()
+			if len(hints) == 0 {
+				dialog.ShowInformation("Tipp", "Es gibt keinen gültigen Zug.", myWindow)
+				return
+			}
+			options := make([]string, len(hints))
+			for i, hint := range hints {
+				options[i] = gui.HintText(hint)
+			}
+			hintSelect := widget.NewSelect(options, nil)
+			hintSelect.SetSelectedIndex(0)
+			formItems := []*widget.FormItem{widget.NewFormItem("Zug", hintSelect)}
+			dialog.ShowForm("Tipp", "Legen", "Abbrechen", formItems, func(confirmed bool) {
+				if !confirmed || hintSelect.SelectedIndex() < 0 {
+					return
+				}
+				if err := mainGrid.PreviewHint(hints[hintSelect.SelectedIndex()]); err != nil {
+					dialog.ShowError(err, myWindow)
+				}
+			}, myWindow)
+		}()
+	})
 
-	actionButtons := container.NewVBox(playButton, passButton, yourNameLabel, yourPointsLabel, remainingTilesLabel)
+	status := gui.NewStatusWidget(myGame, mainGrid.Player)
+
+	// The widgets follow the game by its events
+	myGame.Subscribe(game.NewEventLogger(logger))
+	myGame.Subscribe(mainGrid.HandleEvent)
+	myGame.Subscribe(status.HandleEvent)
+	myGame.Subscribe(func(event game.Event) {
+		if ended, ok := event.(game.GameEnded); ok {
+			dialog.ShowInformation("Spielende", ended.Standings.String(), myWindow)
+		}
+	})
+
+	actionButtons := container.NewVBox(playButton, passButton, exchangeButton, acceptButton, challengeButton, undoButton, redoButton, hintButton, solveButton, status)
 
 	mainLayout := container.NewBorder(nil, nil, nil, actionButtons, mainGrid)
 
@@ -76,3 +342,69 @@ func main() {
 	myWindow.SetContent(mainLayout)
 	myWindow.ShowAndRun()
 }
+
+// loadMoveGenerator creates a move generator for the dictionary of the language, English if none is given. The GADDAG
+// is cached next to the dictionary in the asset directory, or in the user cache directory without one.
+func loadMoveGenerator(languageCode string, assetDirectory string) (game.MoveGenerator, error) {
+	if languageCode == "" {
+		languageCode = "en"
+	}
+	language, err := game.LanguageByCode(languageCode)
+	if err != nil {
+		return nil, err
+	}
+	dictionary, err := language.LoadDictionary()
+	if err != nil {
+		return nil, err
+	}
+	directory := filepath.Join(assetDirectory, game.DictionaryDirectory)
+	if assetDirectory == "" {
+		cacheDirectory, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		directory = filepath.Join(cacheDirectory, "scrabble-go", game.DictionaryDirectory)
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		zap.S().Warnf("Cannot cache gaddag: %s", err)
+	}
+	gaddag, err := linguistic.LoadGaddagForDictionary(dictionary,
+		linguistic.GaddagPath(filepath.Join(directory, language.DictionaryFile)))
+	if err != nil {
+		return nil, err
+	}
+	return linguistic.NewMoveGenerator(gaddag), nil
+}
+
+// loadLexicon combines the dictionaries of the comma separated languages and the words of the addendum file, if any.
+// Without languages, the English dictionary is used.
+func loadLexicon(languageCodes string, addendumPath string) (game.Lexicon, error) {
+	if languageCodes == "" {
+		languageCodes = "en"
+	}
+	lexicons := make([]game.Lexicon, 0)
+	for _, code := range strings.Split(languageCodes, ",") {
+		language, err := game.LanguageByCode(strings.TrimSpace(code))
+		if err != nil {
+			return nil, err
+		}
+		dictionary, err := language.LoadDictionary()
+		if err != nil {
+			return nil, err
+		}
+		lexicons = append(lexicons, dictionary)
+	}
+	if addendumPath != "" {
+		file, err := os.Open(addendumPath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		addendum, err := game.ReadWordList(filepath.Base(addendumPath), file)
+		if err != nil {
+			return nil, err
+		}
+		lexicons = append(lexicons, addendum)
+	}
+	return game.NewUnionLexicon(lexicons...), nil
+}
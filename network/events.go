@@ -0,0 +1,103 @@
+package network
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"game"
+	"reflect"
+)
+
+// wireEvent is an event as sent to peers, one JSON object per line. Type is the name of the event type, which tells the
+// peer how to decode the event.
+type wireEvent struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// eventDecoders holds the events sent to peers by the name of their type. CommandExecuted is left out, as it only
+// repeats the changes published by the other events.
+var eventDecoders = map[string]func(data []byte) (game.Event, error){
+	"PlayerJoined":   decodeEvent[game.PlayerJoined],
+	"TurnChanged":    decodeEvent[game.TurnChanged],
+	"TilePlaced":     decodeEvent[game.TilePlaced],
+	"TileTakenBack":  decodeEvent[game.TileTakenBack],
+	"MovePlayed":     decodeEvent[game.MovePlayed],
+	"MovePending":    decodeEvent[game.MovePending],
+	"MoveChallenged": decodeEvent[game.MoveChallenged],
+	"ScoreChanged":   decodeEvent[game.ScoreChanged],
+	"TilesDrawn":     decodeEvent[game.TilesDrawn],
+	"TilesExchanged": decodeEvent[game.TilesExchanged],
+	"Passed":         decodeEvent[game.Passed],
+	"TurnTakenBack":  decodeEvent[game.TurnTakenBack],
+	"TurnRedone":     decodeEvent[game.TurnRedone],
+	"GameEnded":      decodeEvent[game.GameEnded],
+}
+
+func decodeEvent[E game.Event](data []byte) (game.Event, error) {
+	var event E
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// isSentToPeers checks if the event is sent to peers, see SendEvents
+func isSentToPeers(event game.Event) bool {
+	_, ok := eventDecoders[reflect.TypeOf(event).Name()]
+	return ok
+}
+
+// EncodeEvent encodes the event as a JSON object tagged with the type of the event, which DecodeEvent turns back into
+// the event
+func EncodeEvent(event game.Event) ([]byte, error) {
+	eventType := reflect.TypeOf(event).Name()
+	if _, ok := eventDecoders[eventType]; !ok {
+		return nil, fmt.Errorf("event %T is not sent to peers", event)
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event %T: %w", event, err)
+	}
+	return json.Marshal(wireEvent{Type: eventType, Event: data})
+}
+
+// DecodeEvent decodes an event encoded by EncodeEvent. Players of the event are decoded as new players with the name,
+// score and tiles sent by the peer.
+func DecodeEvent(data []byte) (game.Event, error) {
+	var wire wireEvent
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+	decode, ok := eventDecoders[wire.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type '%s'", wire.Type)
+	}
+	event, err := decode(wire.Event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", wire.Type, err)
+	}
+	return event, nil
+}
+
+// SendEvents returns a subscriber for the events of a game which sends every event to the peer as a line encoded by
+// EncodeEvent. Events which are not sent to peers are skipped, see isSentToPeers.
+func SendEvents(rw *bufio.ReadWriter) game.Subscriber {
+	return func(event game.Event) {
+		if !isSentToPeers(event) {
+			return
+		}
+		data, err := EncodeEvent(event)
+		if err != nil {
+			fmt.Println("Error encoding event:", err)
+			return
+		}
+		if _, err = rw.Write(append(data, '\n')); err != nil {
+			fmt.Println("Error writing event to buffer:", err)
+			return
+		}
+		if err = rw.Flush(); err != nil {
+			fmt.Println("Error flushing buffer:", err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"game"
+	"reflect"
+	"testing"
+)
+
+func TestSendEvents(t *testing.T) {
+	t.Run("Events sent as lines are decoded by the peer", func(t *testing.T) {
+		player := game.NewPlayer("Player 1")
+		player.Tiles = []game.Tile{*game.NewTile("C", 3)}
+		tile := game.NewTile("A", 1)
+		events := []game.Event{
+			game.PlayerJoined{Player: player},
+			game.TilePlaced{Player: player, X: 7, Y: 7, Tile: *tile},
+			game.MovePlayed{Player: player, Move: []game.Move{{X: 7, Y: 7, Tile: tile}}, Score: game.MoveScore{Total: 2}},
+			game.TurnTakenBack{Turn: game.Turn{Kind: game.PassTurn, Player: player}, TilesInBag: 86},
+			game.GameEnded{Standings: game.Standings{Reason: game.PlayerWentOut, WentOut: player}},
+		}
+		var buffer bytes.Buffer
+		rw := bufio.NewReadWriter(bufio.NewReader(&buffer), bufio.NewWriter(&buffer))
+		send := SendEvents(rw)
+		for _, event := range events {
+			send(event)
+		}
+		for _, expected := range events {
+			line, err := rw.ReadBytes('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			event, err := DecodeEvent(line)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expected, event) {
+				t.Errorf("expected %#v, got %#v", expected, event)
+			}
+		}
+	})
+	t.Run("Executed commands are not sent", func(t *testing.T) {
+		var buffer bytes.Buffer
+		rw := bufio.NewReadWriter(bufio.NewReader(&buffer), bufio.NewWriter(&buffer))
+		SendEvents(rw)(game.CommandExecuted{Command: game.PassCommand{}})
+		if buffer.Len() != 0 {
+			t.Errorf("expected no line, got %q", buffer.String())
+		}
+	})
+	t.Run("Unknown event types are rejected", func(t *testing.T) {
+		if _, err := DecodeEvent([]byte(`{"type":"Unknown","event":{}}`)); err == nil {
+			t.Error("expected an error for an unknown event type")
+		}
+	})
+}